@@ -0,0 +1,127 @@
+package integrationtests
+
+import (
+	"log"
+	"testing"
+	"time"
+
+	"github.com/jborkows/timesheets/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// balanceConfig requires only an hour on Mondays, e.g. 4 hours in January and February 2025.
+func balanceConfig() *model.Config {
+	config := model.NewConfig([]string{"aaa"}, "Task-")
+	config.WorkWeek.Hours = map[string]float64{"mon": 1}
+	config.Balance.Since = "2025-01"
+	return config
+}
+
+func TestBalanceShouldRunAcrossMonths(t *testing.T) {
+	t.Parallel()
+	tuesday, _ := time.Parse("2006-01-02", "2025-03-04")
+	useWorkspace(balanceConfig(), func(service *model.Service) {
+		_, _ = service.ProcessForSave("aaa 6.0 january", time.Date(2025, time.January, 6, 0, 0, 0, 0, time.UTC))
+		_, _ = service.ProcessForSave("aaa 4.0 march", time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC))
+
+		content, err := service.ReportContent(service.ReportURI(model.MonthSection, tuesday))
+		if err != nil {
+			log.Fatalf("Failed to generate report: %v", err)
+		}
+
+		assert.Equal(t, `Monthly statistics (4:00/5, 1 to date)
+aaa 4.0
+
+Balance (+1:00)
+2025-01 +2:00
+2025-02 -2:00
+2025-03 +1:00
+`, content)
+		summary, err := service.Summary(tuesday)
+		assert.Nil(t, err)
+		assert.Equal(t, model.SignedMinutes(60), *summary.Balance)
+	})
+}
+
+func TestBalanceShouldLimitCarryOverAndExpire(t *testing.T) {
+	t.Parallel()
+	config := balanceConfig()
+	carryOver := 1.0
+	config.Balance.CarryOver = &carryOver
+	config.Balance.ExpiresAfter = 1
+	tuesday, _ := time.Parse("2006-01-02", "2025-03-04")
+	useWorkspace(config, func(service *model.Service) {
+		_, _ = service.ProcessForSave("aaa 6.0 january", time.Date(2025, time.January, 6, 0, 0, 0, 0, time.UTC))
+		_, _ = service.ProcessForSave("aaa 4.0 february", time.Date(2025, time.February, 3, 0, 0, 0, 0, time.UTC))
+		_, _ = service.ProcessForSave("aaa 2.0 march", time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC))
+
+		balance, err := service.Balance(tuesday)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 3, len(balance))
+		assert.Equal(t, []model.SignedMinutes{60, 0, 60}, []model.SignedMinutes{balance[0].Balance, balance[1].Balance, balance[2].Balance})
+		assert.Equal(t, []model.Minutes{60, 60, 0}, []model.Minutes{balance[0].Expired, balance[1].Expired, balance[2].Expired})
+		assert.Equal(t, model.Minutes(4*60), balance[0].Required)
+	})
+}
+
+func TestBalanceShouldFollowChangedWorkingWeek(t *testing.T) {
+	t.Parallel()
+	config := balanceConfig()
+	tuesday, _ := time.Parse("2006-01-02", "2025-02-04")
+	useWorkspace(config, func(service *model.Service) {
+		_, _ = service.ProcessForSave("aaa 6.0 january", time.Date(2025, time.January, 6, 0, 0, 0, 0, time.UTC))
+
+		config.WorkWeek.Hours = map[string]float64{"mon": 1, "tue": 1}
+		balance, err := service.Balance(tuesday)
+
+		assert.Nil(t, err)
+		assert.Equal(t, model.Minutes(8*60), balance[0].Required)
+		assert.Equal(t, model.SignedMinutes(-2*60), balance[0].Balance)
+	})
+}
+
+func TestBalanceShouldFollowRemovedDays(t *testing.T) {
+	t.Parallel()
+	tuesday, _ := time.Parse("2006-01-02", "2025-01-07")
+	monday := tuesday.AddDate(0, 0, -1)
+	useWorkspace(balanceConfig(), func(service *model.Service) {
+		_, _ = service.ProcessForSave("aaa 3.0 january", monday)
+		assert.Nil(t, service.RemoveDay(monday))
+
+		balance, err := service.Balance(tuesday)
+
+		assert.Nil(t, err)
+		assert.Equal(t, model.SignedMinutes(-60), balance[0].Balance)
+	})
+}
+
+func TestBalanceShouldNotCountHolidaysAsWork(t *testing.T) {
+	t.Parallel()
+	config := balanceConfig()
+	config.Holidays.AddHoc = []string{"2025-01-13"}
+	tuesday, _ := time.Parse("2006-01-02", "2025-01-14")
+	useWorkspace(config, func(service *model.Service) {
+		_, _ = service.ProcessForSave("Holiday", tuesday.AddDate(0, 0, -1))
+
+		balance, err := service.Balance(tuesday.AddDate(0, 1, 0))
+
+		assert.Nil(t, err)
+		assert.Equal(t, model.Minutes(0), balance[0].Worked)
+		assert.Equal(t, model.SignedMinutes(-3*60), balance[0].Balance)
+	})
+}
+
+func TestBalanceShouldNotRequireTimeOnLeave(t *testing.T) {
+	t.Parallel()
+	monday := time.Date(2025, time.January, 6, 0, 0, 0, 0, time.UTC)
+	useWorkspace(balanceConfig(), func(service *model.Service) {
+		_, _ = service.ProcessForSave("@vacation", monday)
+
+		balance, err := service.Balance(monday.AddDate(0, 1, 0))
+
+		assert.Nil(t, err)
+		assert.Equal(t, model.Minutes(3*60), balance[0].Required)
+		assert.Equal(t, model.SignedMinutes(-3*60), balance[0].Balance)
+	})
+}
@@ -0,0 +1,38 @@
+package integrationtests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jborkows/timesheets/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldProposeClosestCategoryForInvalidOne(t *testing.T) {
+	config := model.NewConfig([]string{"development", "meeting", "support"}, "Task-")
+	date := time.Now()
+
+	useWorkspace(config, func(service *model.Service) {
+		fix := service.CategoryFix("  develpment 1.0 first", date)
+		assert.NotNil(t, fix)
+		assert.Equal(t, 2, fix.Column)
+		assert.Equal(t, len("develpment"), fix.Length)
+		assert.Equal(t, "develpment", fix.Category)
+		assert.Equal(t, []string{"development"}, fix.Suggestions)
+
+		assert.Nil(t, service.CategoryFix("development 1.0 first", date))
+	})
+}
+
+func TestShouldCountColumnsOfCategoryFixInCharacters(t *testing.T) {
+	config := model.NewConfig([]string{"wdrożenie"}, "Task-")
+	date := time.Now()
+
+	useWorkspace(config, func(service *model.Service) {
+		fix := service.CategoryFix("wdróżenie 1.0 first", date)
+		assert.NotNil(t, fix)
+		assert.Equal(t, 0, fix.Column)
+		assert.Equal(t, len([]rune("wdróżenie")), fix.Length)
+		assert.Equal(t, []string{"wdrożenie"}, fix.Suggestions)
+	})
+}
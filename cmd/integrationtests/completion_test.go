@@ -0,0 +1,39 @@
+package integrationtests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jborkows/timesheets/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func labels(completions []model.Completion) []string {
+	result := make([]string, 0, len(completions))
+	for _, completion := range completions {
+		result = append(result, completion.Label)
+	}
+	return result
+}
+
+func TestShouldCompleteFromHistoryOfEntries(t *testing.T) {
+	t.Parallel()
+	config := model.NewConfig([]string{"aaa", "bbb"}, "Task-")
+	date, _ := time.Parse("2006-01-02", "2025-03-06")
+	useWorkspace(config, func(service *model.Service) {
+		_, _ = service.ProcessForSave("aaa 1.0 Task-1 review\naaa 1.0 Task-2 coding", date.AddDate(0, 0, -2))
+		_, _ = service.ProcessForSave("aaa 1.0 Task-1 deploy\nbbb 1.0 standup", date.AddDate(0, 0, -1))
+		_, _ = service.ProcessForSave("aaa 2.0 Task-1 review", date)
+
+		assert.Equal(t, []string{"aaa", "bbb"}, labels(service.Completions("a", 1)))
+		assert.Contains(t, labels(service.Completions("aaa 1", 5)), "1.5")
+
+		tasks := service.Completions("aaa 1.0 Ta", 10)
+		assert.Equal(t, []string{"Task-1", "Task-2"}, labels(tasks))
+		assert.Equal(t, 8, tasks[0].Start)
+
+		comments := service.Completions("aaa 1.0 Task-1 ", 15)
+		assert.Equal(t, []string{"review", "deploy"}, labels(comments))
+		assert.Equal(t, []string{"standup"}, labels(service.Completions("bbb 1.0 st", 10)))
+	})
+}
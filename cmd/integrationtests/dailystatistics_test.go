@@ -2,6 +2,7 @@ package integrationtests
 
 import (
 	"log"
+	"net/url"
 	"os"
 	"testing"
 	"time"
@@ -25,14 +26,10 @@ aaa 1h30m second
 aaa 1h45m third`, date)
 		_, _ = service.ProcessForSave(`aaa 1.0 first`, date.AddDate(0, 0, 1))
 		_, _ = service.ProcessForSave(`aaa 1.0 first`, date.AddDate(0, 0, 8))
-		reportFile, err := service.ShowDailyStatistics(date)
+		content, err := service.ReportContent(service.ReportURI(model.DaySection, date))
 		if err != nil {
 			log.Fatalf("Failed to generate report: %v", err)
 		}
-		content, err := os.ReadFile(string(reportFile))
-		if err != nil {
-			log.Fatalf("Failed to read report: %v", err)
-		}
 		log.Printf("Report content: %s", content)
 		desiredContent := `For 2025-03-06
 Daily statistics (7:45)
@@ -57,16 +54,16 @@ ccc 1.5
 
 ####################
 
-Monthly statistics (9:45/24)
+Monthly statistics (9:45/168, 32 to date)
 aaa 6.25
 bbb 2.0
 ccc 1.5
 `
-		assert.Equal(t, desiredContent, string(content))
+		assert.Equal(t, desiredContent, content)
 	})
 }
 
-func TestReportFileShouldBeReused(t *testing.T) {
+func TestReportShouldShowCurrentData(t *testing.T) {
 	config := model.NewConfig([]string{"aaa"}, "Task-")
 	date, err := time.Parse("2006-01-02", "2025-03-06")
 	if err != nil {
@@ -75,19 +72,16 @@ func TestReportFileShouldBeReused(t *testing.T) {
 
 	useWorkspace(config, func(service *model.Service) {
 		_, _ = service.ProcessForSave(`aaa 1.0 first`, date)
-		reportFile, err := service.ShowDailyStatistics(date)
+		uri := service.ReportURI(model.DaySection, date)
+		_, err := service.ReportContent(uri)
 		if err != nil {
 			log.Fatalf("Failed to generate report: %v", err)
 		}
 		_, _ = service.ProcessForSave(`aaa 1.0 first`, date.AddDate(0, 0, 1))
-		_, err = service.ShowDailyStatistics(date)
+		content, err := service.ReportContent(uri)
 		if err != nil {
 			log.Fatalf("Failed to generate report: %v", err)
 		}
-		content, err := os.ReadFile(string(reportFile))
-		if err != nil {
-			log.Fatalf("Failed to read report: %v", err)
-		}
 		log.Printf("Report content: %s", content)
 		desiredContent := `For 2025-03-06
 Daily statistics (1:00)
@@ -102,10 +96,10 @@ aaa 2.0
 
 ####################
 
-Monthly statistics (2:00/16)
+Monthly statistics (2:00/168, 32 to date)
 aaa 2.0
 `
-		assert.Equal(t, desiredContent, string(content))
+		assert.Equal(t, desiredContent, content)
 	})
 }
 
@@ -127,14 +121,10 @@ aaa 1h30m second
 aaa 1h45m third`, date)
 		_, _ = service.ProcessForSave(`aaa 1.0 first`, date.AddDate(0, 0, 1))
 		_, _ = service.ProcessForSave(`aaa 1.0 first`, date.AddDate(0, 0, 8))
-		reportFile, err := service.ShowDailyStatistics(date)
+		content, err := service.ReportContent(service.ReportURI(model.DaySection, date))
 		if err != nil {
 			log.Fatalf("Failed to generate report: %v", err)
 		}
-		content, err := os.ReadFile(string(reportFile))
-		if err != nil {
-			log.Fatalf("Failed to read report: %v", err)
-		}
 		log.Printf("Report content: %s", content)
 		desiredContent := `For 2025-03-06
 Daily statistics (9:45)
@@ -167,7 +157,7 @@ eee 1.0
 
 ####################
 
-Monthly statistics (9:45/24)
+Monthly statistics (9:45/168, 32 to date)
 aaa 6.25
 bbb 2.0
 ccc 1.5
@@ -176,6 +166,40 @@ Overtime (2:00)
 ddd 1.0
 eee 1.0
 `
-		assert.Equal(t, desiredContent, string(content))
+		assert.Equal(t, desiredContent, content)
+	})
+}
+
+func TestReportURIShouldNamePeriodAndDate(t *testing.T) {
+	config := model.NewConfig([]string{"aaa"}, "Task-")
+	date, _ := time.Parse("2006-01-02", "2025-03-06")
+
+	useWorkspace(config, func(service *model.Service) {
+		uri := service.ReportURI(model.WeekSection, date)
+		assert.Equal(t, "timesheet-report://weekly/2025-03-06.txt", uri)
+
+		_, err := service.ReportContent("timesheet-report://yearly/2025-03-06.txt")
+		assert.NotNil(t, err)
+	})
+}
+
+func TestReportFileShouldHoldReportContent(t *testing.T) {
+	config := model.NewConfig([]string{"aaa"}, "Task-")
+	date, _ := time.Parse("2006-01-02", "2025-03-06")
+
+	useWorkspace(config, func(service *model.Service) {
+		_, _ = service.ProcessForSave("aaa 1.0 first", date)
+
+		uri, err := service.ReportFile(model.DaySection, date)
+
+		assert.Nil(t, err)
+		parsed, err := url.Parse(uri)
+		assert.Nil(t, err)
+		assert.Equal(t, "file", parsed.Scheme)
+		written, err := os.ReadFile(parsed.Path)
+		assert.Nil(t, err)
+		content, err := service.ReportContent(service.ReportURI(model.DaySection, date))
+		assert.Nil(t, err)
+		assert.Equal(t, content, string(written))
 	})
 }
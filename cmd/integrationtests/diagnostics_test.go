@@ -0,0 +1,88 @@
+package integrationtests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jborkows/timesheets/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldReportSuspiciousEntriesAsWarnings(t *testing.T) {
+	config := model.NewConfig([]string{"aaa"}, "Task-")
+	date := time.Now()
+
+	useWorkspace(config, func(service *model.Service) {
+		parsed, lineErrors := service.ProcessForDraft(`aaa 9.0 long
+aaa 1.05 fraction
+bbb 1.0 wrong`, date)
+		assert.Equal(t, 2, len(parsed))
+		assert.Equal(t, 3, len(lineErrors))
+
+		assert.ErrorIs(t, lineErrors[0].Err, model.ErrLongEntry)
+		assert.Equal(t, model.SeverityWarning, lineErrors[0].Severity)
+		assert.Equal(t, 0, lineErrors[0].LineNumber)
+		assert.Equal(t, len("aaa "), lineErrors[0].Start)
+		assert.Equal(t, len("aaa 9.0"), lineErrors[0].End)
+
+		assert.ErrorIs(t, lineErrors[1].Err, model.ErrAmbiguousFraction)
+		assert.Equal(t, model.SeverityHint, lineErrors[1].Severity)
+		assert.Equal(t, 1, lineErrors[1].LineNumber)
+
+		assert.ErrorIs(t, lineErrors[2].Err, model.ErrInvalidCategory)
+		assert.Equal(t, model.SeverityError, lineErrors[2].Severity)
+		assert.Equal(t, 2, lineErrors[2].LineNumber)
+		assert.Equal(t, 0, lineErrors[2].Start)
+		assert.Equal(t, len("bbb"), lineErrors[2].End)
+	})
+}
+
+func TestShouldCountColumnsOfWarningsInCharacters(t *testing.T) {
+	config := model.NewConfig([]string{"wdrożenie"}, "Task-")
+	date := time.Now()
+
+	useWorkspace(config, func(service *model.Service) {
+		_, lineErrors := service.ProcessForDraft("wdrożenie 9.0 long", date)
+		assert.Equal(t, 1, len(lineErrors))
+		assert.ErrorIs(t, lineErrors[0].Err, model.ErrLongEntry)
+		assert.Equal(t, len([]rune("wdrożenie ")), lineErrors[0].Start)
+		assert.Equal(t, len([]rune("wdrożenie 9.0")), lineErrors[0].End)
+	})
+}
+
+func TestShouldWarnAboutOverlappingClockRanges(t *testing.T) {
+	config := model.NewConfig([]string{"aaa"}, "Task-")
+	date := time.Now()
+
+	useWorkspace(config, func(service *model.Service) {
+		parsed, lineErrors := service.ProcessForDraft(`aaa 09:00-10:00 first
+aaa 10:00-11:00 second
+aaa 10:30-11:30 third`, date)
+		assert.Equal(t, 3, len(parsed))
+		assert.Equal(t, 1, len(lineErrors))
+		assert.ErrorIs(t, lineErrors[0].Err, model.ErrOverlappingRange)
+		assert.Equal(t, model.SeverityWarning, lineErrors[0].Severity)
+		assert.Equal(t, 2, lineErrors[0].LineNumber)
+		assert.Equal(t, len("aaa "), lineErrors[0].Start)
+		assert.Equal(t, len("aaa 10:30-11:30"), lineErrors[0].End)
+	})
+}
+
+func TestShouldWarnAboutGapsBetweenClockRanges(t *testing.T) {
+	config := model.NewConfig([]string{"aaa"}, "Task-")
+	date := time.Now()
+
+	useWorkspace(config, func(service *model.Service) {
+		_, lineErrors := service.ProcessForDraft(`aaa 11:00-12:00 late
+aaa 09:00-10:00 early
+aaa 1.0 duration only
+aaa 09:30-10:30 overlapping`, date)
+		assert.Equal(t, 2, len(lineErrors))
+		assert.ErrorIs(t, lineErrors[0].Err, model.ErrOverlappingRange)
+		assert.Equal(t, 3, lineErrors[0].LineNumber)
+		assert.ErrorIs(t, lineErrors[1].Err, model.ErrUnaccountedGap)
+		assert.Equal(t, "unaccounted time 10:30-11:00 before this entry", lineErrors[1].Err.Error())
+		assert.Equal(t, model.SeverityWarning, lineErrors[1].Severity)
+		assert.Equal(t, 0, lineErrors[1].LineNumber)
+	})
+}
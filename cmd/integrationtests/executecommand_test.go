@@ -0,0 +1,82 @@
+package integrationtests
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	messages "github.com/jborkows/timesheets/internal/lspmessages"
+	"github.com/jborkows/timesheets/internal/lspserver"
+	"github.com/jborkows/timesheets/internal/model"
+	"github.com/jborkows/timesheets/internal/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+type sentMessage struct {
+	ID     *int                    `json:"id"`
+	Method string                  `json:"method"`
+	Result json.RawMessage         `json:"result"`
+	Error  *messages.ResponseError `json:"error"`
+}
+
+// sent reads messages written by controller since the last call.
+func sent(t *testing.T, output *bytes.Buffer) []sentMessage {
+	var result []sentMessage
+	scanner := bufio.NewScanner(output)
+	scanner.Split(rpc.Split)
+	for scanner.Scan() {
+		_, contents, err := rpc.DecodeMessage(scanner.Bytes())
+		assert.Nil(t, err)
+		var message sentMessage
+		assert.Nil(t, json.Unmarshal(contents, &message))
+		result = append(result, message)
+	}
+	return result
+}
+
+func createDay(t *testing.T, controller *lspserver.Controller, id int, date string) {
+	request, err := json.Marshal(messages.ExecuteCommandRequest{
+		Request: messages.Request{RPC: "2.0", ID: id, Method: "workspace/executeCommand"},
+		Params:  messages.ExecuteCommandParams{Command: messages.CreateDayCommand, Arguments: []any{date}},
+	})
+	assert.Nil(t, err)
+	controller.HandleMessage("workspace/executeCommand", request)
+}
+
+func answerEdit(controller *lspserver.Controller, id int, applied bool) {
+	controller.HandleMessage("", []byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":{"applied":%t,"failureReason":"cancelled"}}`, id, applied)))
+}
+
+func TestShouldAnswerCommandOnceEditIsApplied(t *testing.T) {
+	t.Parallel()
+	config := model.NewConfig([]string{"aaa"}, "Task-")
+	repository, cleanup := initDB(config)
+	defer cleanup()
+	var output bytes.Buffer
+	controller := lspserver.NewController(&lspserver.ControllerConfig{
+		Service: model.NewService(t.TempDir(), config, repository),
+		Writer:  &output,
+	})
+
+	createDay(t, controller, 1, "2025-03-06")
+	replies := sent(t, &output)
+	assert.Len(t, replies, 1)
+	assert.Equal(t, "workspace/applyEdit", replies[0].Method)
+
+	answerEdit(controller, *replies[0].ID, false)
+	replies = sent(t, &output)
+	assert.Len(t, replies, 1)
+	assert.Equal(t, 1, *replies[0].ID)
+	assert.Contains(t, replies[0].Error.Message, "cancelled")
+
+	createDay(t, controller, 2, "2025-03-06")
+	edit := sent(t, &output)[0]
+	answerEdit(controller, *edit.ID, true)
+	replies = sent(t, &output)
+	assert.Len(t, replies, 2)
+	assert.Equal(t, "window/showDocument", replies[0].Method)
+	assert.Equal(t, 2, *replies[1].ID)
+	assert.Nil(t, replies[1].Error)
+}
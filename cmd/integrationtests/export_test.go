@@ -0,0 +1,29 @@
+package integrationtests
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jborkows/timesheets/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldExportMonthInGivenFormat(t *testing.T) {
+	t.Parallel()
+	config := model.NewConfig([]string{"aaa", "bbb"}, "Task-")
+	date, _ := time.Parse("2006-01-02", "2025-03-06")
+	useWorkspace(config, func(service *model.Service) {
+		_, _ = service.ProcessForSave("aaa 1.0 first\nbbb 0.5 other", date)
+		_, _ = service.ProcessForSave("aaa 2.0 first", date.AddDate(0, 0, 1))
+
+		text, err := service.MonthExport(date, model.CSVFormat)
+
+		assert.Nil(t, err)
+		assert.Equal(t, `section,category,task,comment,minutes,hours
+monthly,aaa,,,180,3.00
+monthly,bbb,,,30,0.50
+`, text)
+		assert.True(t, strings.HasSuffix(service.ExportURI(date, model.CSVFormat), "/exports/2025-03.csv"))
+	})
+}
@@ -0,0 +1,56 @@
+package integrationtests
+
+import (
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jborkows/timesheets/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldFollowFilesChangedOutsideOfEditor(t *testing.T) {
+	t.Parallel()
+	config := model.NewConfig([]string{"aaa", "bbb"}, "Task-")
+	repository, cleanup := initDB(config)
+	defer cleanup()
+	root := t.TempDir()
+	service := model.NewService(root, config, repository)
+	date, _ := time.Parse("2006-01-02", "2025-03-06")
+	_, _ = service.ProcessForSave("aaa 8.0 from editor", date)
+
+	path := writeTimesheetFile(t, root, "2025/03/06.tsf", "aaa 1.0 pulled\nbbb 0.5 from git\n")
+	uri := (&url.URL{Scheme: "file", Path: path}).String()
+	text, errors, err := service.ProcessFromDisk(uri)
+
+	assert.Nil(t, err)
+	assert.Empty(t, errors)
+	assert.Equal(t, "aaa 1.0 pulled\nbbb 0.5 from git\n", text)
+	statistics, err := service.RangeStatistics(date, date, model.GroupByCategory)
+	assert.Nil(t, err)
+	assert.Equal(t, []model.RangeStatistic{
+		{Category: "aaa", Dirty: 60, Total: 60},
+		{Category: "bbb", Dirty: 30, Total: 30},
+	}, statistics)
+
+	assert.Nil(t, os.Remove(path))
+	assert.Nil(t, service.RemoveDay(date))
+	statistics, err = service.RangeStatistics(date, date, model.GroupByCategory)
+	assert.Nil(t, err)
+	assert.Empty(t, statistics)
+}
+
+func TestShouldForgetDayWhenSavedFileHasNoEntries(t *testing.T) {
+	t.Parallel()
+	config := model.NewConfig([]string{"aaa"}, "Task-")
+	date, _ := time.Parse("2006-01-02", "2025-03-06")
+	useWorkspace(config, func(service *model.Service) {
+		_, _ = service.ProcessForSave("aaa 8.0 first version", date)
+		_, _ = service.ProcessForSave("", date)
+
+		statistics, err := service.RangeStatistics(date, date, model.GroupByCategory)
+		assert.Nil(t, err)
+		assert.Empty(t, statistics)
+	})
+}
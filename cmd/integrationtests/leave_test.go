@@ -0,0 +1,73 @@
+package integrationtests
+
+import (
+	"log"
+	"testing"
+	"time"
+
+	"github.com/jborkows/timesheets/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeaveShouldBeReportedSeparately(t *testing.T) {
+	t.Parallel()
+	config := model.NewConfig([]string{"aaa"}, "Task-")
+	config.Leave.Allowance = 26
+	monday, _ := time.Parse("2006-01-02", "2025-03-03")
+	tuesday := monday.AddDate(0, 0, 1)
+	useWorkspace(config, func(service *model.Service) {
+		_, _ = service.ProcessForSave("@vacation", monday)
+		_, _ = service.ProcessForSave("aaa 4.0 first\n@vacation half", tuesday)
+		_, _ = service.ProcessForSave("@sick 2h", tuesday.AddDate(0, 0, 1))
+
+		content, err := service.ReportContent(service.ReportURI(model.MonthSection, tuesday))
+		if err != nil {
+			log.Fatalf("Failed to generate report: %v", err)
+		}
+
+		assert.Equal(t, `Monthly statistics (4:00/154, 4 to date)
+aaa 4.0
+
+Leave (14:00)
+sick 2.0
+vacation 12.0
+vacation days 1.5/26, 24.5 left
+`, content)
+	})
+}
+
+func TestLeaveShouldLowerExpectedTime(t *testing.T) {
+	t.Parallel()
+	config := model.NewConfig([]string{"aaa"}, "Task-")
+	tuesday, _ := time.Parse("2006-01-02", "2025-03-04")
+	useWorkspace(config, func(service *model.Service) {
+		_, _ = service.ProcessForSave("aaa 2.0 first\n@sick 6h", tuesday)
+
+		progress := service.DayProgress([]string{"aaa 2.0 first", "@sick 6h"}, tuesday)
+		assert.Equal(t, 1, len(progress))
+		assert.Equal(t, model.Minutes(2*60), progress[0].Expected)
+
+		summary, err := service.Summary(tuesday)
+		assert.Nil(t, err)
+		assert.Equal(t, model.PeriodSummary{Worked: 2 * 60, Expected: 2 * 60}, summary.Day)
+		assert.Equal(t, model.Minutes(34*60), summary.Week.Expected)
+	})
+}
+
+func TestShouldCompleteLeaveKinds(t *testing.T) {
+	t.Parallel()
+	config := model.NewConfig([]string{"aaa"}, "Task-")
+	useWorkspace(config, func(service *model.Service) {
+		completions := service.Completions("@va", 3)
+		labels := []string{}
+		for _, completion := range completions {
+			labels = append(labels, completion.Label)
+		}
+		assert.Equal(t, []string{"@vacation", "@sick", "@unpaid"}, labels)
+		assert.Equal(t, 0, completions[0].Start)
+
+		completions = service.Completions("@sick h", 7)
+		assert.Equal(t, "half", completions[0].Label)
+		assert.Equal(t, 6, completions[0].Start)
+	})
+}
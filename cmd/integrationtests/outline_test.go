@@ -0,0 +1,55 @@
+package integrationtests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jborkows/timesheets/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldGroupEntriesOfTheDayByCategory(t *testing.T) {
+	t.Parallel()
+	config := model.NewConfig([]string{"aaa", "bbb"}, "Task-")
+	date, _ := time.Parse("2006-01-02", "2025-03-06")
+	useWorkspace(config, func(service *model.Service) {
+		outline := service.DayOutline([]string{
+			"aaa 1h30m Task-1 first",
+			"bbb 0.5",
+			"xxx 1.0 invalid",
+			"aaa 1.0 second",
+		}, date)
+
+		assert.Equal(t, []model.CategoryOutline{
+			{Category: "aaa", Time: 150, Entries: []model.OutlineEntry{
+				{Name: "Task-1 first", Time: 90, LineNumber: 0, EndColumn: len("aaa 1h30m Task-1 first")},
+				{Name: "second", Time: 60, LineNumber: 3, EndColumn: len("aaa 1.0 second")},
+			}},
+			{Category: "bbb", Time: 30, Entries: []model.OutlineEntry{
+				{Name: "bbb", Time: 30, LineNumber: 1, EndColumn: len("bbb 0.5")},
+			}},
+		}, outline)
+	})
+}
+
+func TestShouldFoldConsecutiveEntriesOfTheSameCategory(t *testing.T) {
+	t.Parallel()
+	config := model.NewConfig([]string{"aaa", "bbb"}, "Task-")
+	date, _ := time.Parse("2006-01-02", "2025-03-06")
+	useWorkspace(config, func(service *model.Service) {
+		folds := service.CategoryFolds([]string{
+			"aaa 1.0 first",
+			"aaa 1.0 second",
+			"aaa 1.0 third",
+			"bbb 1.0 single",
+			"",
+			"bbb 1.0 first",
+			"bbb 1.0 second",
+		}, date)
+
+		assert.Equal(t, []model.Fold{
+			{Category: "aaa", StartLine: 0, EndLine: 2},
+			{Category: "bbb", StartLine: 5, EndLine: 6},
+		}, folds)
+	})
+}
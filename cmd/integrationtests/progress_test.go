@@ -0,0 +1,28 @@
+package integrationtests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jborkows/timesheets/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldShowRunningTotalOfTheDay(t *testing.T) {
+	t.Parallel()
+	config := model.NewConfig([]string{"aaa", "bbb"}, "Task-")
+	date, _ := time.Parse("2006-01-02", "2025-03-06")
+	useWorkspace(config, func(service *model.Service) {
+		progress := service.DayProgress([]string{
+			"aaa 1h30m Task-1 first",
+			"xxx 1.0 invalid",
+			"",
+			"bbb 09:00-09:45 standup",
+		}, date)
+
+		assert.Equal(t, []model.LineProgress{
+			{LineNumber: 0, EndColumn: len("aaa 1h30m Task-1 first"), Time: 90, RunningTotal: 90, Expected: 8 * 60},
+			{LineNumber: 3, EndColumn: len("bbb 09:00-09:45 standup"), Time: 45, RunningTotal: 135, Expected: 8 * 60},
+		}, progress)
+	})
+}
@@ -0,0 +1,70 @@
+package integrationtests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jborkows/timesheets/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldFindTaskAcrossFiles(t *testing.T) {
+	t.Parallel()
+	config := model.NewConfig([]string{"aaa", "bbb"}, "Task-")
+	repository, cleanup := initDB(config)
+	defer cleanup()
+	root := t.TempDir()
+	writeTimesheetFile(t, root, "2025/03/05.tsf", "aaa 1.0 Task-1 first\nbbb 1.0 Task-2 other\n")
+	writeTimesheetFile(t, root, "2025/03/06.tsf", "bbb 1.0 Task-2 other\naaa 0.5 Task-1 second\n")
+	service := model.NewService(root, config, repository)
+	_, err := service.Reindex()
+	assert.Nil(t, err)
+	march5, _ := time.Parse("2006-01-02", "2025-03-05")
+	march6 := march5.AddDate(0, 0, 1)
+	opened := map[string][]string{
+		service.FileForDate(march6): {"", "bbb 1.0 Task-2 other", "aaa 0.5 Task-1 moved in editor"},
+	}
+
+	references, err := service.TaskReferences("Task-1", func(uri string) []string { return opened[uri] })
+
+	assert.Nil(t, err)
+	assert.Equal(t, []model.TaskReference{
+		{URI: service.FileForDate(march5), Occurrence: model.Occurrence{LineNumber: 0, Start: 8, End: 14}},
+		{URI: service.FileForDate(march6), Occurrence: model.Occurrence{LineNumber: 2, Start: 8, End: 14}},
+	}, references)
+}
+
+func TestShouldFindTaskUnderCursor(t *testing.T) {
+	t.Parallel()
+	config := model.NewConfig([]string{"aaa"}, "Task-")
+	date := time.Now()
+	useWorkspace(config, func(service *model.Service) {
+		task, ok := service.TaskAt("aaa 1.0 Task-1 first", 10, date)
+		assert.True(t, ok)
+		assert.Equal(t, "Task-1", task)
+
+		_, ok = service.TaskAt("aaa 1.0 Task-1 first", 16, date)
+		assert.False(t, ok)
+		_, ok = service.TaskAt("aaa 1.0 first", 10, date)
+		assert.False(t, ok)
+	})
+}
+
+func TestShouldCountColumnsOfTaskInCharacters(t *testing.T) {
+	t.Parallel()
+	config := model.NewConfig([]string{"wdrożenie"}, "Task-")
+	date := time.Now()
+	useWorkspace(config, func(service *model.Service) {
+		line := "wdrożenie 1.0 Task-1 first"
+		start := len([]rune("wdrożenie 1.0 "))
+
+		task, ok := service.TaskAt(line, start+len("Task-1"), date)
+		assert.True(t, ok)
+		assert.Equal(t, "Task-1", task)
+		_, ok = service.TaskAt(line, start-2, date)
+		assert.False(t, ok)
+
+		assert.Equal(t, []model.Occurrence{{LineNumber: 1, Start: start, End: start + len("Task-1")}},
+			service.TaskOccurrences([]string{"wdrożenie 1.0 first", line}, "Task-1", date))
+	})
+}
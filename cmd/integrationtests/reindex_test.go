@@ -0,0 +1,50 @@
+package integrationtests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jborkows/timesheets/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTimesheetFile(t *testing.T, root string, name string, content string) string {
+	path := filepath.Join(root, name)
+	assert.Nil(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestShouldRebuildDatabaseFromFiles(t *testing.T) {
+	t.Parallel()
+	config := model.NewConfig([]string{"aaa", "bbb"}, "Task-")
+	repository, cleanup := initDB(config)
+	defer cleanup()
+	root := t.TempDir()
+	writeTimesheetFile(t, root, "2025/03/06.tsf", "aaa 1.5 Task-1 first\nbbb 0.5 second\n")
+	broken := writeTimesheetFile(t, root, "2025/03/07.tsf", "aaa 2.0 third\nxxx 1.0 unknown\n")
+	writeTimesheetFile(t, root, "2025/03/notes.txt", "aaa 8.0 not a timesheet\n")
+	service := model.NewService(root, config, repository)
+	stale, _ := time.Parse("2006-01-02", "2025-03-05")
+	_, _ = service.ProcessForSave("aaa 8.0 removed from disk", stale)
+
+	result, err := service.Reindex()
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, result.Files)
+	assert.Len(t, result.Errors, 1)
+	assert.Equal(t, broken, result.Errors[0].Path)
+	assert.Equal(t, 1, result.Errors[0].Errors[0].LineNumber)
+	assert.ErrorIs(t, result.Errors[0].Errors[0].Err, model.ErrInvalidCategory)
+
+	from, _ := time.Parse("2006-01-02", "2025-03-01")
+	to, _ := time.Parse("2006-01-02", "2025-03-31")
+	statistics, err := service.RangeStatistics(from, to, model.GroupByCategory)
+	assert.Nil(t, err)
+	assert.Equal(t, []model.RangeStatistic{
+		{Category: "aaa", Dirty: 3*60 + 30, Total: 3*60 + 30},
+		{Category: "bbb", Dirty: 30, Total: 30},
+	}, statistics)
+}
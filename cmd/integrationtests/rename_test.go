@@ -0,0 +1,194 @@
+package integrationtests
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	messages "github.com/jborkows/timesheets/internal/lspmessages"
+	"github.com/jborkows/timesheets/internal/lspserver"
+	"github.com/jborkows/timesheets/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldRenameTaskOnceEditIsApplied(t *testing.T) {
+	t.Parallel()
+	config := model.NewConfig([]string{"aaa", "bbb"}, "Task-")
+	repository, cleanup := initDB(config)
+	defer cleanup()
+	root := t.TempDir()
+	writeTimesheetFile(t, root, "2025/03/05.tsf", "aaa 1.0 Task-1 first\nbbb 1.0 Task-2 other\n")
+	writeTimesheetFile(t, root, "2025/03/06.tsf", "bbb 1.0 Task-2 other\n")
+	service := model.NewService(root, config, repository)
+	_, err := service.Reindex()
+	assert.Nil(t, err)
+	march5, _ := time.Parse("2006-01-02", "2025-03-05")
+	noneOpened := func(uri string) []string { return nil }
+
+	_, err = service.Rename(model.TaskRename, "Task-2", "Bug-1", noneOpened)
+	assert.ErrorIs(t, err, model.ErrInvalidName)
+
+	edits, err := service.Rename(model.TaskRename, "Task-2", "Task-3", noneOpened)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []model.FileEdit{
+		{URI: service.FileForDate(march5), Occurrences: []model.Occurrence{{LineNumber: 1, Start: 8, End: 14}}},
+		{URI: service.FileForDate(march5.AddDate(0, 0, 1)), Occurrences: []model.Occurrence{{LineNumber: 0, Start: 8, End: 14}}},
+	}, edits)
+	statistics, err := service.RangeStatistics(march5, march5.AddDate(0, 0, 1), model.GroupByTask)
+	assert.Nil(t, err)
+	assert.Equal(t, "Task-2", statistics[1].Task, "data is not changed before the edit is applied")
+
+	assert.Nil(t, service.ApplyRename(model.TaskRename, "Task-2", "Task-3"))
+	statistics, err = service.RangeStatistics(march5, march5.AddDate(0, 0, 1), model.GroupByTask)
+	assert.Nil(t, err)
+	assert.Equal(t, []model.RangeStatistic{
+		{Category: "aaa", Task: "Task-1", Dirty: 60, Total: 60},
+		{Category: "bbb", Task: "Task-3", Dirty: 120, Total: 120},
+	}, statistics)
+}
+
+func TestShouldRenameCategoryInDataAndConfigurationOnceEditIsApplied(t *testing.T) {
+	t.Parallel()
+	config := model.NewConfig([]string{"aaa", "bbb"}, "Task-")
+	repository, cleanup := initDB(config)
+	defer cleanup()
+	root := t.TempDir()
+	writeTimesheetFile(t, root, "2025/03/05.tsf", "aaa 1.0 first\n  bbb 1.0 other\n")
+	service := model.NewService(root, config, repository)
+	_, err := service.Reindex()
+	assert.Nil(t, err)
+	noneOpened := func(uri string) []string { return nil }
+	date, _ := time.Parse("2006-01-02", "2025-03-05")
+
+	_, err = service.Rename(model.CategoryRename, "bbb", "aaa", noneOpened)
+	assert.ErrorIs(t, err, model.ErrInvalidName)
+
+	edits, err := service.Rename(model.CategoryRename, "bbb", "ccc", noneOpened)
+
+	assert.Nil(t, err)
+	assert.Len(t, edits, 1)
+	assert.Equal(t, []model.Occurrence{{LineNumber: 1, Start: 2, End: 5}}, edits[0].Occurrences)
+	assert.Equal(t, []string{"aaa", "bbb"}, service.PossibleCategories(), "configuration is not changed before the edit is applied")
+
+	assert.Nil(t, service.ApplyRename(model.CategoryRename, "bbb", "ccc"))
+	assert.Equal(t, []string{"aaa", "ccc"}, service.PossibleCategories())
+	statistics, err := service.RangeStatistics(date, date, model.GroupByCategory)
+	assert.Nil(t, err)
+	assert.Equal(t, "ccc", statistics[1].Category)
+
+	_, lineErrors := service.ProcessForSave("aaa 1.0 first\n  ccc 1.0 other\n", date)
+	assert.Empty(t, lineErrors, "file saved before config file uses configured category")
+	result, err := service.ReplaceConfig(model.NewConfig([]string{"aaa", "ccc"}, "Task-"))
+	assert.Nil(t, err)
+	assert.Nil(t, result, "saved config file is already in use")
+	statistics, err = service.RangeStatistics(date, date, model.GroupByCategory)
+	assert.Nil(t, err)
+	assert.Equal(t, []model.RangeStatistic{{Category: "aaa", Dirty: 60, Total: 60}, {Category: "ccc", Dirty: 60, Total: 60}}, statistics)
+
+	result, err = service.ReplaceConfig(model.NewConfig([]string{"aaa", "ccc", "ddd"}, "Task-"))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, result.Files)
+}
+
+func TestShouldRenameCategoryWithCharactersOutsideOfASCII(t *testing.T) {
+	t.Parallel()
+	config := model.NewConfig([]string{"wdrożenie"}, "Task-")
+	repository, cleanup := initDB(config)
+	defer cleanup()
+	root := t.TempDir()
+	writeTimesheetFile(t, root, "2025/03/05.tsf", "wdrożenie 1.0 Task-1 żółć\n")
+	service := model.NewService(root, config, repository)
+	_, err := service.Reindex()
+	assert.Nil(t, err)
+	date, _ := time.Parse("2006-01-02", "2025-03-05")
+
+	target, ok := service.RenameTargetAt("wdrożenie 1.0 Task-1 żółć", 0, len([]rune("wdrożenie")), date)
+	assert.True(t, ok)
+	assert.Equal(t, model.Occurrence{LineNumber: 0, Start: 0, End: len([]rune("wdrożenie"))}, target.Occurrence)
+	target, ok = service.RenameTargetAt("wdrożenie 1.0 Task-1 żółć", 0, len([]rune("wdrożenie 1.0 Task-1")), date)
+	assert.True(t, ok)
+	assert.Equal(t, model.Occurrence{LineNumber: 0, Start: 14, End: 20}, target.Occurrence)
+
+	edits, err := service.Rename(model.CategoryRename, "wdrożenie", "rollout", func(uri string) []string { return nil })
+
+	assert.Nil(t, err)
+	assert.Equal(t, []model.Occurrence{{LineNumber: 0, Start: 0, End: 9}}, edits[0].Occurrences)
+}
+
+func TestShouldFindRenameTarget(t *testing.T) {
+	t.Parallel()
+	config := model.NewConfig([]string{"aaa"}, "Task-")
+	useWorkspace(config, func(service *model.Service) {
+		target, ok := service.RenameTargetAt("aaa 1.0 Task-1 first", 3, 1, time.Now())
+		assert.True(t, ok)
+		assert.Equal(t, &model.RenameTarget{Kind: model.CategoryRename, Name: "aaa", Occurrence: model.Occurrence{LineNumber: 3, Start: 0, End: 3}}, target)
+
+		target, ok = service.RenameTargetAt("aaa 1.0 Task-1 first", 3, 9, time.Now())
+		assert.True(t, ok)
+		assert.Equal(t, model.TaskRename, target.Kind)
+
+		_, ok = service.RenameTargetAt("aaa 1.0 Task-1 first", 3, 17, time.Now())
+		assert.False(t, ok)
+	})
+}
+
+func TestShouldRenameStoredDataOnlyWhenClientAppliedTheEdit(t *testing.T) {
+	t.Parallel()
+	config := model.NewConfig([]string{"aaa"}, "Task-")
+	repository, cleanup := initDB(config)
+	defer cleanup()
+	root := t.TempDir()
+	writeTimesheetFile(t, root, "2025/03/05.tsf", "aaa 1.0 Task-1 first\n")
+	service := model.NewService(root, config, repository)
+	_, err := service.Reindex()
+	assert.Nil(t, err)
+	date, _ := time.Parse("2006-01-02", "2025-03-05")
+	var output bytes.Buffer
+	controller := lspserver.NewController(&lspserver.ControllerConfig{Service: service, Writer: &output})
+	uri := service.FileForDate(date)
+	opened, err := json.Marshal(messages.DidOpenTextDocumentNotification{
+		Notification: messages.Notification{RPC: "2.0", Method: "textDocument/didOpen"},
+		Params:       messages.DidOpenTextDocumentParams{TextDocument: messages.TextDocumentItem{URI: uri, Text: "aaa 1.0 Task-1 first\n"}},
+	})
+	assert.Nil(t, err)
+	controller.HandleMessage("textDocument/didOpen", opened)
+	rename := func(id int, newName string) {
+		request, err := json.Marshal(messages.RenameRequest{
+			Request: messages.Request{RPC: "2.0", ID: id, Method: "textDocument/rename"},
+			Params: messages.RenameParams{
+				TextDocumentPositionParams: messages.TextDocumentPositionParams{
+					TextDocument: messages.TextDocumentIdentifier{URI: uri},
+					Position:     messages.Position{Line: 0, Character: 10},
+				},
+				NewName: newName,
+			},
+		})
+		assert.Nil(t, err)
+		controller.HandleMessage("textDocument/rename", request)
+	}
+	tasks := func() []string {
+		statistics, err := service.RangeStatistics(date, date, model.GroupByTask)
+		assert.Nil(t, err)
+		return []string{statistics[0].Task}
+	}
+
+	rename(1, "Task-2")
+	replies := sent(t, &output)
+	assert.Len(t, replies, 1)
+	assert.Equal(t, "workspace/applyEdit", replies[0].Method)
+	answerEdit(controller, *replies[0].ID, false)
+	replies = sent(t, &output)
+	assert.Len(t, replies, 1)
+	assert.NotNil(t, replies[0].Error)
+	assert.Equal(t, []string{"Task-1"}, tasks())
+
+	rename(2, "Task-2")
+	replies = sent(t, &output)
+	answerEdit(controller, *replies[0].ID, true)
+	replies = sent(t, &output)
+	assert.Nil(t, replies[len(replies)-1].Error)
+	assert.Equal(t, 2, *replies[len(replies)-1].ID)
+	assert.Equal(t, []string{"Task-2"}, tasks())
+}
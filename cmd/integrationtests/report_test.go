@@ -0,0 +1,47 @@
+package integrationtests
+
+import (
+	"bytes"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/jborkows/timesheets/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteRangeReport(t *testing.T) {
+	config := model.NewConfig([]string{"aaa", "bbb"}, "Task-")
+	from, err := time.Parse("2006-01-02", "2025-02-20")
+	if err != nil {
+		log.Fatalf("Failed to parse date: %v", err)
+	}
+
+	useWorkspace(config, func(service *model.Service) {
+		_, _ = service.ProcessForSave(`aaa 1.0 Task-1 first
+bbb 2.0 description`, from)
+		_, _ = service.ProcessForSave(`aaa 1h30m Task-1 second
+aaa 0.25 Task-2 third`, from.AddDate(0, 0, 14))
+		_, _ = service.ProcessForSave(`aaa 8.0 outside`, from.AddDate(0, 0, 15))
+
+		var output bytes.Buffer
+		renderer, _ := model.RendererFor(model.TextFormat)
+		report, err := service.RangeReport(from, from.AddDate(0, 0, 14), model.GroupByCategory)
+		assert.Nil(t, err)
+		assert.Nil(t, renderer.Render(&output, report))
+		assert.Equal(t, `Range statistics 2025-02-20 - 2025-03-06 (4:45)
+aaa 2.75
+bbb 2.0
+`, output.String())
+
+		output.Reset()
+		report, err = service.RangeReport(from, from.AddDate(0, 0, 14), model.GroupByTask)
+		assert.Nil(t, err)
+		assert.Nil(t, renderer.Render(&output, report))
+		assert.Equal(t, `Range statistics 2025-02-20 - 2025-03-06 (4:45)
+aaa Task-1 2.5
+aaa Task-2 0.25
+bbb 2.0
+`, output.String())
+	})
+}
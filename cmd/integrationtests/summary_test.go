@@ -0,0 +1,27 @@
+package integrationtests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jborkows/timesheets/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldSummarizeDayWeekAndMonth(t *testing.T) {
+	t.Parallel()
+	config := model.NewConfig([]string{"aaa", "bbb"}, "Task-")
+	thursday, _ := time.Parse("2006-01-02", "2025-03-06")
+	useWorkspace(config, func(service *model.Service) {
+		_, _ = service.ProcessForSave("aaa 8.0 first", thursday.AddDate(0, 0, -3))
+		_, _ = service.ProcessForSave("aaa 4.0 second\nbbb 2.5 third", thursday)
+		_, _ = service.ProcessForDraft("aaa 4.0 second\nbbb 3.0 third", thursday)
+
+		summary, err := service.Summary(thursday)
+
+		assert.Nil(t, err)
+		assert.Equal(t, model.PeriodSummary{Worked: 7 * 60, Expected: 8 * 60}, summary.Day)
+		assert.Equal(t, model.PeriodSummary{Worked: 15 * 60, Expected: 40 * 60}, summary.Week)
+		assert.Equal(t, model.PeriodSummary{Worked: 15 * 60, Expected: 168 * 60, ExpectedToDate: 32 * 60}, summary.Month)
+	})
+}
@@ -0,0 +1,43 @@
+package integrationtests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jborkows/timesheets/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldFindWorkspaceSymbols(t *testing.T) {
+	t.Parallel()
+	config := model.NewConfig([]string{"aaa", "bbb"}, "Task-")
+	repository, cleanup := initDB(config)
+	defer cleanup()
+	root := t.TempDir()
+	writeTimesheetFile(t, root, "2025/03/05.tsf", "aaa 1.0 Task-1 first\nbbb 1.0 Task-2 other\n")
+	writeTimesheetFile(t, root, "2025/03/06.tsf", "bbb 1.0 Task-2 other\naaa 0.5 Task-1 second\n")
+	service := model.NewService(root, config, repository)
+	_, err := service.Reindex()
+	assert.Nil(t, err)
+	march5, _ := time.Parse("2006-01-02", "2025-03-05")
+	march6 := march5.AddDate(0, 0, 1)
+	noneOpened := func(uri string) []string { return nil }
+
+	symbols, err := service.Symbols("Task-1", noneOpened)
+	assert.Nil(t, err)
+	assert.Equal(t, []model.Symbol{
+		{Name: "Task-1", Kind: model.TaskSymbol, Detail: "last used 2025-03-06", URI: service.FileForDate(march6), Occurrence: model.Occurrence{LineNumber: 1, Start: 8, End: 14}},
+	}, symbols)
+
+	symbols, err = service.Symbols("bb", noneOpened)
+	assert.Nil(t, err)
+	assert.Equal(t, []model.Symbol{
+		{Name: "bbb", Kind: model.CategorySymbol, Detail: "last used 2025-03-06", URI: service.FileForDate(march6), Occurrence: model.Occurrence{LineNumber: 0, Start: 0, End: 3}},
+	}, symbols)
+
+	symbols, err = service.Symbols("2025-03-05", noneOpened)
+	assert.Nil(t, err)
+	assert.Equal(t, []model.Symbol{
+		{Name: "2025-03-05", Kind: model.DaySymbol, Detail: "Wednesday", URI: service.FileForDate(march5)},
+	}, symbols)
+}
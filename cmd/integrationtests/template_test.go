@@ -0,0 +1,135 @@
+package integrationtests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jborkows/timesheets/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldPrefillDayFromTemplate(t *testing.T) {
+	t.Parallel()
+	config := model.NewConfig([]string{"aaa"}, "Task-")
+	config.Template.Lines = []string{"aaa 0.25 daily standup", "aaa 0.5 review"}
+	config.Holidays.Repeatable = []string{"05-01"}
+	thursday, _ := time.Parse("2006-01-02", "2025-03-06")
+	saturday, _ := time.Parse("2006-01-02", "2025-03-08")
+	mayDay, _ := time.Parse("2006-01-02", "2025-05-01")
+	useWorkspace(config, func(service *model.Service) {
+		assert.Equal(t, "aaa 0.25 daily standup\naaa 0.5 review\n", service.DayTemplate(thursday))
+		assert.Equal(t, "", service.DayTemplate(saturday))
+		assert.Equal(t, "Holiday\n", service.DayTemplate(mayDay))
+	})
+}
+
+func TestShouldCreateDayFileOnlyOnce(t *testing.T) {
+	t.Parallel()
+	config := model.NewConfig([]string{"aaa"}, "Task-")
+	config.Template.Lines = []string{"aaa 0.25 daily standup"}
+	repository, cleanup := initDB(config)
+	defer cleanup()
+	root := t.TempDir()
+	service := model.NewService(root, config, repository)
+	date, _ := time.Parse("2006-01-02", "2025-03-06")
+
+	day, err := service.CreateDay(date)
+	assert.Nil(t, err)
+	assert.True(t, day.Created)
+	assert.Empty(t, day.Errors)
+	path := day.Path
+	assert.Equal(t, filepath.Join(root, "2025", "03", "06.tsf"), path)
+	content, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, "aaa 0.25 daily standup\n", string(content))
+	statistics, err := service.DayStatistics(date)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, statistics)
+
+	assert.Nil(t, os.WriteFile(path, []byte("aaa 1.0 edited\n"), 0o644))
+	day, err = service.CreateDay(date)
+	assert.Nil(t, err)
+	assert.False(t, day.Created)
+	content, _ = os.ReadFile(path)
+	assert.Equal(t, "aaa 1.0 edited\n", string(content))
+}
+
+func TestShouldReportTemplateLinesWhichCannotBeStored(t *testing.T) {
+	t.Parallel()
+	config := model.NewConfig([]string{"aaa"}, "Task-")
+	config.Template.Lines = []string{"aaa 0.25 daily standup", "zzz 1.0 unknown category"}
+	repository, cleanup := initDB(config)
+	defer cleanup()
+	service := model.NewService(t.TempDir(), config, repository)
+	date, _ := time.Parse("2006-01-02", "2025-03-06")
+
+	day, err := service.CreateDay(date)
+
+	assert.Nil(t, err)
+	assert.True(t, day.Created)
+	assert.Len(t, day.Errors, 1)
+	assert.Equal(t, 1, day.Errors[0].LineNumber)
+	statistics, err := service.DayStatistics(date)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, statistics)
+}
+
+func recurringConfig() *model.Config {
+	toml := `
+[categories]
+regular=["aaa", "bbb"]
+[holidays]
+repeatable=["05-01"]
+[tasks]
+prefix="Task-"
+onlyNumbers=true
+[[recurring]]
+category="aaa"
+duration="0.25"
+comment="daily standup"
+[[recurring]]
+category="bbb"
+duration="1h"
+task="Task-7"
+comment="planning"
+weekdays=["mon"]
+[[recurring]]
+category="bbb"
+duration="0.5"
+comment="invoices"
+monthdays=[8]
+`
+	config, err := model.ReadConfig(strings.NewReader(toml))
+	if err != nil {
+		panic(err)
+	}
+	return config
+}
+
+func TestShouldAddRecurringEntriesToTemplate(t *testing.T) {
+	t.Parallel()
+	monday, _ := time.Parse("2006-01-02", "2025-03-03")
+	saturday, _ := time.Parse("2006-01-02", "2025-03-08")
+	mayDay, _ := time.Parse("2006-01-02", "2025-05-01")
+	useWorkspace(recurringConfig(), func(service *model.Service) {
+		assert.Equal(t, "aaa 0.25 daily standup\nbbb 1h Task-7 planning\n", service.DayTemplate(monday))
+		assert.Equal(t, "bbb 0.5 invoices\n", service.DayTemplate(saturday))
+		assert.Equal(t, "Holiday\n", service.DayTemplate(mayDay))
+	})
+}
+
+func TestShouldFindMissingRecurringEntries(t *testing.T) {
+	t.Parallel()
+	monday, _ := time.Parse("2006-01-02", "2025-03-03")
+	useWorkspace(recurringConfig(), func(service *model.Service) {
+		missing := service.MissingRecurring([]string{
+			"aaa 0.5 daily standup",
+			"bbb 1h planning",
+		}, monday)
+
+		assert.Equal(t, []string{"bbb 1h Task-7 planning"}, missing)
+	})
+}
@@ -0,0 +1,38 @@
+package integrationtests
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jborkows/timesheets/internal/lspserver"
+	"github.com/jborkows/timesheets/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldRegisterFileWatchersOnlyWhenClientSupportsIt(t *testing.T) {
+	t.Parallel()
+	config := model.NewConfig([]string{"aaa"}, "Task-")
+	repository, cleanup := initDB(config)
+	defer cleanup()
+	for capabilities, registrations := range map[string]int{
+		`{}`: 0,
+		`{"workspace":{"didChangeWatchedFiles":{"dynamicRegistration":false}}}`: 0,
+		`{"workspace":{"didChangeWatchedFiles":{"dynamicRegistration":true}}}`:  1,
+	} {
+		var output bytes.Buffer
+		controller := lspserver.NewController(&lspserver.ControllerConfig{
+			Service: model.NewService(t.TempDir(), config, repository),
+			Writer:  &output,
+		})
+		controller.HandleMessage("initialize", []byte(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"clientInfo":{"name":"test"},"capabilities":`+capabilities+`}}`))
+		_ = sent(t, &output)
+
+		controller.HandleMessage("initialized", []byte(`{"jsonrpc":"2.0","method":"initialized","params":{}}`))
+
+		replies := sent(t, &output)
+		assert.Len(t, replies, registrations, capabilities)
+		for _, reply := range replies {
+			assert.Equal(t, "client/registerCapability", reply.Method)
+		}
+	}
+}
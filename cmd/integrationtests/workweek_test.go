@@ -0,0 +1,57 @@
+package integrationtests
+
+import (
+	"log"
+	"testing"
+	"time"
+
+	"github.com/jborkows/timesheets/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeekendWorkShouldBeOvertime(t *testing.T) {
+	t.Parallel()
+	config := model.NewConfig([]string{"aaa"}, "Task-")
+	config.Categories.Overtime = []string{"ddd"}
+	thursday, _ := time.Parse("2006-01-02", "2025-03-06")
+	saturday := thursday.AddDate(0, 0, 2)
+	useWorkspace(config, func(service *model.Service) {
+		_, _ = service.ProcessForSave("aaa 6.0 first\nddd 1.0 release", thursday)
+		_, _ = service.ProcessForSave("aaa 3.0 fixes", saturday)
+
+		content, err := service.ReportContent(service.ReportURI(model.MonthSection, thursday))
+		if err != nil {
+			log.Fatalf("Failed to generate report: %v", err)
+		}
+
+		assert.Equal(t, `Monthly statistics (6:00/168, 32 to date)
+aaa 6.0
+
+Overtime (4:00)
+aaa 3.0
+ddd 1.0
+`, content)
+	})
+}
+
+func TestShouldExpectHoursOfWorkWeek(t *testing.T) {
+	t.Parallel()
+	config := model.NewConfig([]string{"aaa"}, "Task-")
+	config.WorkWeek.Hours = map[string]float64{"mon": 8, "tue": 8, "wed": 8, "thu": 8, "fri": 6, "sat": 4}
+	friday, _ := time.Parse("2006-01-02", "2025-03-07")
+	saturday := friday.AddDate(0, 0, 1)
+	useWorkspace(config, func(service *model.Service) {
+		_, _ = service.ProcessForSave("aaa 6.0 first", friday)
+		_, _ = service.ProcessForSave("aaa 1.0 second", saturday)
+
+		progress := service.DayProgress([]string{"aaa 1.0 second"}, saturday)
+		assert.Equal(t, model.Minutes(4*60), progress[0].Expected)
+
+		summary, err := service.Summary(friday)
+		assert.Nil(t, err)
+		assert.Equal(t, model.Minutes(42*60), summary.Week.Expected)
+		assert.Equal(t, model.Minutes(180*60), summary.Month.Expected)
+		assert.Equal(t, model.Minutes(42*60), summary.Month.ExpectedToDate)
+		assert.Equal(t, model.Minutes(7*60), summary.Month.Worked)
+	})
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"bufio"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
@@ -24,10 +25,32 @@ func main() {
 	}
 	defer logger.Close()
 
+	if len(os.Args) > 1 {
+		var run func(args []string) error
+		switch os.Args[1] {
+		case "report":
+			run = runReport
+		case "reindex":
+			run = runReindex
+		case "new":
+			run = runNewDay
+		}
+		if run != nil {
+			if err := run(os.Args[2:]); err != nil {
+				log.Printf("Command %s failed: %s", os.Args[1], err)
+				fmt.Fprintf(os.Stderr, "%s: %s\n", os.Args[1], err)
+				logger.Close()
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
 	var versionFlag = flag.Bool("version", false, "Print version")
 	var configFlag = flag.String("c", "", "Path to config file")
 	var reloadFlag = flag.Bool("lsptesting", false, "For air and lsp testing")
 	var projectRootFlag = flag.String("project-root", "", "Project root")
+	var reindexFlag = flag.Bool("reindex", false, "Rebuild database from timesheet files on start")
 	flag.Parse()
 	if *versionFlag {
 		fmt.Printf("Version: %s", model.Version)
@@ -40,32 +63,26 @@ func main() {
 	if *configFlag == "" {
 		log.Fatal("Config file is required")
 	}
-
-	file, err := os.Open(*configFlag)
-	if err != nil {
-		log.Fatalf("Error opening file: %v", err)
-	}
-	defer file.Close()
 	if *projectRootFlag != "" {
 		log.Println("Project root is set to", *projectRootFlag)
 	} else {
 		log.Fatal("Project root is required")
 	}
-	config, err := model.ReadConfig(file)
-
-	log.Printf("Config: %+v", config)
-	if err != nil {
-		log.Fatalf("Error reading config file: %s", err)
-	}
+	config := readConfig(*configFlag)
 
+	freshDatabase := !model.Exists(databasePath(*projectRootFlag))
 	repository, cleanup := initDB(*projectRootFlag, config)
 	defer cleanup()
 	writer := os.Stdout
 
 	service := model.NewService(*projectRootFlag, config, repository)
+	if freshDatabase || *reindexFlag {
+		reindexOnStart(service)
+	}
 	controller := lspserver.NewController(&lspserver.ControllerConfig{
-		Service: service,
-		Writer:  writer,
+		Service:    service,
+		Writer:     writer,
+		ConfigPath: *configFlag,
 	})
 	scanner := bufio.NewScanner(os.Stdin)
 	scanner.Split(rpc.Split)
@@ -82,10 +99,32 @@ func main() {
 	}
 }
 
+// errInvalidLines fails command after lines which could not be stored were listed.
+var errInvalidLines = errors.New("some lines could not be stored")
+
+func readConfig(configPath string) *model.Config {
+	file, err := os.Open(configPath)
+	if err != nil {
+		log.Fatalf("Error opening file: %v", err)
+	}
+	defer file.Close()
+	config, err := model.ReadConfig(file)
+
+	log.Printf("Config: %+v", config)
+	if err != nil {
+		log.Fatalf("Error reading config file: %s", err)
+	}
+	return config
+}
+
 type cleanupFunction func()
 
+func databasePath(projectRoot string) string {
+	return filepath.Join(projectRoot, "timesheets.db")
+}
+
 func initDB(projectRoot string, config *model.Config) (model.Repository, cleanupFunction) {
-	dbPath := filepath.Join(projectRoot, "timesheets.db")
+	dbPath := databasePath(projectRoot)
 	if !model.Exists(dbPath) {
 		_, err := os.Create(dbPath)
 		if err != nil {
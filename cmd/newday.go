@@ -0,0 +1,50 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/jborkows/timesheets/internal/model"
+)
+
+// runNewDay creates file of the day from template, e.g.
+// timesheets new -c config.toml --project-root . --date 2025-03-06
+func runNewDay(args []string) error {
+	flags := flag.NewFlagSet("new", flag.ExitOnError)
+	configFlag := flags.String("c", "", "Path to config file")
+	projectRootFlag := flags.String("project-root", "", "Project root")
+	dateFlag := flags.String("date", "", "Day to create (YYYY-MM-DD), today by default")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *configFlag == "" {
+		return fmt.Errorf("config file is required")
+	}
+	if *projectRootFlag == "" {
+		return fmt.Errorf("project root is required")
+	}
+	date, err := parseDay(*dateFlag, today())
+	if err != nil {
+		return fmt.Errorf("invalid date: %w", err)
+	}
+
+	config := readConfig(*configFlag)
+	repository, cleanup := initDB(*projectRootFlag, config)
+	defer cleanup()
+	service := model.NewService(*projectRootFlag, config, repository)
+
+	day, err := service.CreateDay(date)
+	if err != nil {
+		return err
+	}
+	if !day.Created {
+		fmt.Fprintf(os.Stderr, "%s already exists\n", day.Path)
+	}
+	writeFileErrors(os.Stderr, day.Path, day.Errors)
+	fmt.Println(day.Path)
+	if len(day.Errors) > 0 {
+		return errInvalidLines
+	}
+	return nil
+}
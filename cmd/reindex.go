@@ -0,0 +1,68 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/jborkows/timesheets/internal/model"
+)
+
+// runReindex rebuilds database from timesheet files, e.g. after pulling them from git
+// timesheets reindex -c config.toml --project-root .
+func runReindex(args []string) error {
+	flags := flag.NewFlagSet("reindex", flag.ExitOnError)
+	configFlag := flags.String("c", "", "Path to config file")
+	projectRootFlag := flags.String("project-root", "", "Project root")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *configFlag == "" {
+		return fmt.Errorf("config file is required")
+	}
+	if *projectRootFlag == "" {
+		return fmt.Errorf("project root is required")
+	}
+
+	config := readConfig(*configFlag)
+	repository, cleanup := initDB(*projectRootFlag, config)
+	defer cleanup()
+	service := model.NewService(*projectRootFlag, config, repository)
+
+	result, err := service.Reindex()
+	if err != nil {
+		return err
+	}
+	writeReindexErrors(os.Stderr, result)
+	fmt.Printf("Reindexed %d files\n", result.Files)
+	if len(result.Errors) > 0 {
+		return errInvalidLines
+	}
+	return nil
+}
+
+// writeReindexErrors lists problems as path:line:column: message, lines and columns counted from 1.
+func writeReindexErrors(output io.Writer, result *model.ReindexResult) {
+	for _, file := range result.Errors {
+		writeFileErrors(output, file.Path, file.Errors)
+	}
+}
+
+func writeFileErrors(output io.Writer, path string, lineErrors []model.LineError) {
+	for _, lineError := range lineErrors {
+		fmt.Fprintf(output, "%s:%d:%d: %s\n", path, lineError.LineNumber+1, lineError.Start+1, lineError.Err)
+	}
+}
+
+// reindexOnStart fills database when it was just created or on user request.
+// Errors are only logged as stdout belongs to the LSP client.
+func reindexOnStart(service *model.Service) {
+	result, err := service.Reindex()
+	if err != nil {
+		log.Printf("Reindex on start failed: %s", err)
+		return
+	}
+	writeReindexErrors(log.Writer(), result)
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/jborkows/timesheets/internal/model"
+)
+
+type reportOptions struct {
+	period  string
+	date    time.Time
+	from    time.Time
+	to      time.Time
+	format  model.ReportFormat
+	groupBy model.GroupBy
+}
+
+// runReport prints statistics to stdout without starting the LSP server, e.g.
+// timesheets report -c config.toml --project-root . --period range --from 2025-01-01 --to 2025-03-31
+func runReport(args []string) error {
+	flags := flag.NewFlagSet("report", flag.ExitOnError)
+	configFlag := flags.String("c", "", "Path to config file")
+	projectRootFlag := flags.String("project-root", "", "Project root")
+	periodFlag := flags.String("period", "daily", "Report period: daily, weekly, monthly or range")
+	dateFlag := flags.String("date", "", "Day for daily, weekly and monthly reports (YYYY-MM-DD), today by default")
+	fromFlag := flags.String("from", "", "First day of range report (YYYY-MM-DD)")
+	toFlag := flags.String("to", "", "Last day of range report (YYYY-MM-DD), today by default")
+	formatFlag := flags.String("format", "text", "Output format: text, json, csv or markdown")
+	groupByFlag := flags.String("group-by", "category", "Grouping of range report: category or task")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *configFlag == "" {
+		return fmt.Errorf("config file is required")
+	}
+	if *projectRootFlag == "" {
+		return fmt.Errorf("project root is required")
+	}
+	options, err := parseReportOptions(*periodFlag, *dateFlag, *fromFlag, *toFlag, *formatFlag, *groupByFlag)
+	if err != nil {
+		return err
+	}
+
+	config := readConfig(*configFlag)
+	repository, cleanup := initDB(*projectRootFlag, config)
+	defer cleanup()
+	service := model.NewService(*projectRootFlag, config, repository)
+
+	log.Printf("Writing %s report %+v", options.period, options)
+	return writeReport(os.Stdout, service, options)
+}
+
+func parseReportOptions(period, date, from, to, format, groupBy string) (*reportOptions, error) {
+	options := &reportOptions{period: period}
+	var err error
+	if options.format, err = model.ParseReportFormat(format); err != nil {
+		return nil, err
+	}
+	today := today()
+	if options.date, err = parseDay(date, today); err != nil {
+		return nil, fmt.Errorf("invalid date: %w", err)
+	}
+	if options.to, err = parseDay(to, today); err != nil {
+		return nil, fmt.Errorf("invalid end of range: %w", err)
+	}
+	if from != "" && period == "daily" {
+		options.period = "range"
+	}
+	switch options.period {
+	case "daily", "weekly", "monthly":
+	case "range":
+		if from == "" {
+			return nil, fmt.Errorf("range report requires --from")
+		}
+		if options.from, err = parseDay(from, today); err != nil {
+			return nil, fmt.Errorf("invalid beginning of range: %w", err)
+		}
+		if options.from.After(options.to) {
+			return nil, fmt.Errorf("range beginning %s is after its end %s", from, options.to.Format("2006-01-02"))
+		}
+	default:
+		return nil, fmt.Errorf("unknown period %q", period)
+	}
+	switch groupBy {
+	case "category":
+		options.groupBy = model.GroupByCategory
+	case "task":
+		options.groupBy = model.GroupByTask
+	default:
+		return nil, fmt.Errorf("unknown grouping %q", groupBy)
+	}
+	return options, nil
+}
+
+// today is the local date, stored the same way as parsed dates.
+func today() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func parseDay(value string, fallback time.Time) (time.Time, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+func buildReport(service *model.Service, options *reportOptions) (*model.Report, error) {
+	switch options.period {
+	case "daily":
+		return service.DayReport(options.date)
+	case "weekly":
+		return service.WeekReport(options.date)
+	case "monthly":
+		return service.MonthReport(options.date)
+	case "range":
+		return service.RangeReport(options.from, options.to, options.groupBy)
+	}
+	return nil, fmt.Errorf("unknown period %q", options.period)
+}
+
+func writeReport(output io.Writer, service *model.Service, options *reportOptions) error {
+	renderer, err := model.RendererFor(options.format)
+	if err != nil {
+		return err
+	}
+	report, err := buildReport(service, options)
+	if err != nil {
+		return err
+	}
+	return renderer.Render(output, report)
+}
@@ -10,28 +10,28 @@ import (
 	"strings"
 )
 
-const findMonthlyOngoingStatistics = `-- name: FindMonthlyOngoingStatistics :one
-select counted_days from monthly_ongoing_report_data where month = ?1 and pending = ?2
+const findMonthlyOvertimeStatistics = `-- name: FindMonthlyOvertimeStatistics :many
+select month, pending, category, holiday, overtime, hours, minutes from monthly_report_data where month = ?1 and (overtime = 1 or category in (/*SLICE:categories*/?))
 `
 
-type FindMonthlyOngoingStatisticsParams struct {
-	Date    interface{}
-	Pending bool
-}
-
-func (q *Queries) FindMonthlyOngoingStatistics(ctx context.Context, arg FindMonthlyOngoingStatisticsParams) (int64, error) {
-	row := q.db.QueryRowContext(ctx, findMonthlyOngoingStatistics, arg.Date, arg.Pending)
-	var counted_days int64
-	err := row.Scan(&counted_days)
-	return counted_days, err
+type FindMonthlyOvertimeStatisticsParams struct {
+	Date       interface{}
+	Categories []string
 }
 
-const findMonthlyStatistics = `-- name: FindMonthlyStatistics :many
-select month, pending, category, holiday, hours, minutes from monthly_report_data where month = ?1
-`
-
-func (q *Queries) FindMonthlyStatistics(ctx context.Context, date interface{}) ([]MonthlyReportDatum, error) {
-	rows, err := q.db.QueryContext(ctx, findMonthlyStatistics, date)
+func (q *Queries) FindMonthlyOvertimeStatistics(ctx context.Context, arg FindMonthlyOvertimeStatisticsParams) ([]MonthlyReportDatum, error) {
+	query := findMonthlyOvertimeStatistics
+	var queryParams []interface{}
+	queryParams = append(queryParams, arg.Date)
+	if len(arg.Categories) > 0 {
+		for _, v := range arg.Categories {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:categories*/?", strings.Repeat(",?", len(arg.Categories))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:categories*/?", "NULL", 1)
+	}
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
 	if err != nil {
 		return nil, err
 	}
@@ -44,6 +44,7 @@ func (q *Queries) FindMonthlyStatistics(ctx context.Context, date interface{}) (
 			&i.Pending,
 			&i.Category,
 			&i.Holiday,
+			&i.Overtime,
 			&i.Hours,
 			&i.Minutes,
 		); err != nil {
@@ -60,17 +61,17 @@ func (q *Queries) FindMonthlyStatistics(ctx context.Context, date interface{}) (
 	return items, nil
 }
 
-const findMonthlyStatisticsForCategories = `-- name: FindMonthlyStatisticsForCategories :many
-select month, pending, category, holiday, hours, minutes from monthly_report_data where month = ?1 and category in (/*SLICE:categories*/?)
+const findMonthlyRegularStatistics = `-- name: FindMonthlyRegularStatistics :many
+select month, pending, category, holiday, overtime, hours, minutes from monthly_report_data where month = ?1 and overtime = 0 and category in (/*SLICE:categories*/?)
 `
 
-type FindMonthlyStatisticsForCategoriesParams struct {
+type FindMonthlyRegularStatisticsParams struct {
 	Date       interface{}
 	Categories []string
 }
 
-func (q *Queries) FindMonthlyStatisticsForCategories(ctx context.Context, arg FindMonthlyStatisticsForCategoriesParams) ([]MonthlyReportDatum, error) {
-	query := findMonthlyStatisticsForCategories
+func (q *Queries) FindMonthlyRegularStatistics(ctx context.Context, arg FindMonthlyRegularStatisticsParams) ([]MonthlyReportDatum, error) {
+	query := findMonthlyRegularStatistics
 	var queryParams []interface{}
 	queryParams = append(queryParams, arg.Date)
 	if len(arg.Categories) > 0 {
@@ -94,6 +95,42 @@ func (q *Queries) FindMonthlyStatisticsForCategories(ctx context.Context, arg Fi
 			&i.Pending,
 			&i.Category,
 			&i.Holiday,
+			&i.Overtime,
+			&i.Hours,
+			&i.Minutes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findMonthlyStatistics = `-- name: FindMonthlyStatistics :many
+select month, pending, category, holiday, overtime, hours, minutes from monthly_report_data where month = ?1
+`
+
+func (q *Queries) FindMonthlyStatistics(ctx context.Context, date interface{}) ([]MonthlyReportDatum, error) {
+	rows, err := q.db.QueryContext(ctx, findMonthlyStatistics, date)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []MonthlyReportDatum
+	for rows.Next() {
+		var i MonthlyReportDatum
+		if err := rows.Scan(
+			&i.Month,
+			&i.Pending,
+			&i.Category,
+			&i.Holiday,
+			&i.Overtime,
 			&i.Hours,
 			&i.Minutes,
 		); err != nil {
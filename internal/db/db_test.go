@@ -41,7 +41,7 @@ func useDb(t *testing.T, test func(saver model.Saver, querier model.Queryer)) {
 
 	support := dbp.NewTransactionSupport(db)
 	err = support.WithTransaction(context.Background(), func(ctx context.Context, q *dbp.Queries) error {
-		repository := dbp.Repository(q, func(model.CategoryType) bool { return false })
+		repository := dbp.Repository(q, func(model.CategoryType) bool { return false }, model.DefaultWorkWeek())
 		test(repository, repository)
 		return nil
 	})
@@ -73,7 +73,7 @@ func TestSaveDoesNotCorruptStdout(t *testing.T) {
 
 	support := dbp.NewTransactionSupport(db)
 	err = support.WithTransaction(context.Background(), func(ctx context.Context, q *dbp.Queries) error {
-		repository := dbp.Repository(q, func(model.CategoryType) bool { return false })
+		repository := dbp.Repository(q, func(model.CategoryType) bool { return false }, model.DefaultWorkWeek())
 
 		// Create and save a timesheet with entries
 		timesheet := model.TimesheetForDate(time.Now())
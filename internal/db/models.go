@@ -4,6 +4,15 @@
 
 package db
 
+import (
+	"database/sql"
+)
+
+type BalanceDatum struct {
+	Month  int64
+	Worked int64
+}
+
 type DailyReportDatum struct {
 	Date     int64
 	Pending  bool
@@ -13,6 +22,14 @@ type DailyReportDatum struct {
 	Minutes  int64
 }
 
+type LeaveDatum struct {
+	ID            int64
+	TimesheetDate int64
+	Kind          string
+	Minutes       int64
+	Comment       string
+}
+
 type MonthlyOngoingReportDatum struct {
 	Month       interface{}
 	Pending     bool
@@ -24,6 +41,7 @@ type MonthlyReportDatum struct {
 	Pending  bool
 	Category string
 	Holiday  bool
+	Overtime bool
 	Hours    int64
 	Minutes  int64
 }
@@ -45,6 +63,9 @@ type TimesheetEntryDatum struct {
 	Task          string
 	Category      string
 	Month         interface{}
+	StartMinute   sql.NullInt64
+	EndMinute     sql.NullInt64
+	Overtime      bool
 }
 
 type WeeklyReportDatum struct {
@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"strconv"
 	"time"
@@ -19,12 +20,14 @@ const (
 type impl struct {
 	queries  *Queries
 	overtime func(model.CategoryType) bool
+	workWeek *model.WorkWeek
 }
 
-func Repository(queries *Queries, overtime func(model.CategoryType) bool) *impl {
+func Repository(queries *Queries, overtime func(model.CategoryType) bool, workWeek *model.WorkWeek) *impl {
 	return &impl{
 		queries:  queries,
 		overtime: overtime,
+		workWeek: workWeek,
 	}
 }
 
@@ -33,6 +36,10 @@ func (repository *impl) Save(ctx context.Context, timesheet *model.Timesheet) er
 	if err != nil {
 		return fmt.Errorf("failed to clear time sheet data: %w", err)
 	}
+	err = repository.queries.ClearLeaves(ctx, dayAsInteger(&timesheet.Date))
+	if err != nil {
+		return fmt.Errorf("failed to clear leaves: %w", err)
+	}
 	err = repository.saveTimeSheet(ctx, timesheet, Saved)
 	if err != nil {
 		return fmt.Errorf("failed to save time sheet: %w", err)
@@ -44,6 +51,53 @@ func (repository *impl) Save(ctx context.Context, timesheet *model.Timesheet) er
 	return nil
 }
 
+func (self *impl) Clear(ctx context.Context) error {
+	err := self.queries.ClearTimesheets(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to clear timesheets: %w", err)
+	}
+	err = self.queries.ClearBalances(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to clear balances: %w", err)
+	}
+	return nil
+}
+
+func (self *impl) Remove(ctx context.Context, knowsAboutDate model.KnowsAboutDate) error {
+	err := self.queries.RemoveTimesheet(ctx, dayAsInteger(knowsAboutDate.Day()))
+	if err != nil {
+		return fmt.Errorf("failed to remove timesheet: %w", err)
+	}
+	return nil
+}
+
+func (self *impl) Rename(ctx context.Context, kind model.RenameKind, oldName string, newName string) error {
+	var err error
+	switch kind {
+	case model.CategoryRename:
+		err = self.queries.RenameCategory(ctx, RenameCategoryParams{NewName: newName, OldName: oldName})
+	case model.TaskRename:
+		err = self.queries.RenameTask(ctx, RenameTaskParams{NewName: newName, OldName: oldName})
+	default:
+		return fmt.Errorf("unknown rename of %s", kind)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to rename %s %s: %w", kind, oldName, err)
+	}
+	return nil
+}
+
+func (self *impl) SaveBalance(ctx context.Context, ledger model.LedgerMonth) error {
+	err := self.queries.SaveBalance(ctx, SaveBalanceParams{
+		Month:  dayAsInteger(&ledger.Month) / 100,
+		Worked: int64(ledger.Worked),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save balance: %w", err)
+	}
+	return nil
+}
+
 func (self *impl) PendingSave(ctx context.Context, timesheet *model.Timesheet) error {
 	err := self.queries.ClearPending(ctx, dayAsInteger(&timesheet.Date))
 	if err != nil {
@@ -62,6 +116,7 @@ func (self *impl) saveTimeSheet(ctx context.Context, timesheet *model.Timesheet,
 	if err != nil {
 		return fmt.Errorf("failed to create time sheet: %w", err)
 	}
+	overtimeDay := self.workWeek.IsOvertimeDay(time.Time(timesheet.Date))
 	for _, entry := range timesheet.Entries {
 		switch e := entry.(type) {
 		case *model.Holiday:
@@ -83,11 +138,28 @@ func (self *impl) saveTimeSheet(ctx context.Context, timesheet *model.Timesheet,
 				Comment:       e.Comment,
 				Task:          e.TaskName(),
 				Category:      e.Category,
+				StartMinute:   startMinute(e.Range),
+				EndMinute:     endMinute(e.Range),
+				Overtime:      overtimeDay,
 			}
 			err := self.queries.AddEntry(ctx, savingDate)
 			if err != nil {
 				return fmt.Errorf("failed to insert pending: %w", err)
 			}
+		case *model.Leave:
+			// leave is reported only once saved
+			if pending {
+				continue
+			}
+			err := self.queries.AddLeave(ctx, AddLeaveParams{
+				TimesheetDate: dayAsInteger(&timesheet.Date),
+				Kind:          e.Kind,
+				Minutes:       int64(e.Time),
+				Comment:       e.Comment,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to insert leave: %w", err)
+			}
 		}
 	}
 	return nil
@@ -242,14 +314,8 @@ func (self *impl) Monthly(ctx context.Context, knowsAboutMonth model.KnowsAboutM
 	return result, nil
 }
 
-func (self *impl) MonthlyPerCategories(ctx context.Context, categories []string, knowsAboutMonth model.KnowsAboutMonth) ([]model.MonthlyStatistic, error) {
-	month := knowsAboutMonth.Month()
-	values, err := self.queries.FindMonthlyStatisticsForCategories(context.TODO(), FindMonthlyStatisticsForCategoriesParams{Date: dayAsInteger(&month.BeginDate) / 100, Categories: categories})
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to find statistics: %w", err)
-	}
-	result := groupData(structDataParams[MonthlyReportDatum, model.MonthlyStatistic]{
+func monthlyStatistics(self *impl, values []MonthlyReportDatum) []model.MonthlyStatistic {
+	return groupData(structDataParams[MonthlyReportDatum, model.MonthlyStatistic]{
 		self: self,
 		data: values,
 		toSelect: func(entry MonthlyReportDatum) selectOutput {
@@ -268,21 +334,30 @@ func (self *impl) MonthlyPerCategories(ctx context.Context, categories []string,
 			}
 		},
 	})
-	return result, nil
 }
 
-func (self *impl) MonthlyOngoing(ctx context.Context, knowsAboutMonth model.KnowsAboutMonth) (model.TotalHours, error) {
+func (self *impl) MonthlyRegular(ctx context.Context, categories []string, knowsAboutMonth model.KnowsAboutMonth) ([]model.MonthlyStatistic, error) {
 	month := knowsAboutMonth.Month()
-	counted_days, err := self.queries.FindMonthlyOngoingStatistics(ctx, FindMonthlyOngoingStatisticsParams{
-
-		Date:    dayAsInteger(&month.BeginDate) / 100,
-		Pending: false,
-	})
+	values, err := self.queries.FindMonthlyRegularStatistics(ctx, FindMonthlyRegularStatisticsParams{Date: dayAsInteger(&month.BeginDate) / 100, Categories: categories})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find statistics: %w", err)
+	}
+	return monthlyStatistics(self, values), nil
+}
 
+func (self *impl) MonthlyOvertime(ctx context.Context, categories []string, knowsAboutMonth model.KnowsAboutMonth) ([]model.MonthlyStatistic, error) {
+	month := knowsAboutMonth.Month()
+	values, err := self.queries.FindMonthlyOvertimeStatistics(ctx, FindMonthlyOvertimeStatisticsParams{Date: dayAsInteger(&month.BeginDate) / 100, Categories: categories})
 	if err != nil {
-		return 0, fmt.Errorf("failed to find statistics: %w", err)
+		return nil, fmt.Errorf("failed to find statistics: %w", err)
+	}
+	result := monthlyStatistics(self, values)
+	// regular categories are here only because of work on overtime days
+	for i := range result {
+		result[i].Dirty.Overtime = true
+		result[i].Monthly.Overtime = true
 	}
-	return model.TotalHours(uint16(counted_days) * 8), nil
+	return result, nil
 }
 
 func (self *impl) DaySummary(ctx context.Context, dateKnower model.KnowsAboutDate) ([]model.DayEntry, error) {
@@ -302,9 +377,220 @@ func (self *impl) DaySummary(ctx context.Context, dateKnower model.KnowsAboutDat
 			Comment:       value.Comment,
 			Task:          value.Task,
 			Category:      value.Category,
+			Range:         timeRange(value.StartMinute, value.EndMinute),
+		})
+	}
+
+	return result, nil
+}
+
+type rangeRow struct {
+	Category string
+	Task     string
+	Pending  bool
+	Minutes  int64
+}
+
+func (self *impl) Range(ctx context.Context, from model.Day, to model.Day, groupBy model.GroupBy) ([]model.RangeStatistic, error) {
+	var rows []rangeRow
+	switch groupBy {
+	case model.GroupByCategory:
+		values, err := self.queries.FindRangeStatisticsPerCategory(ctx, FindRangeStatisticsPerCategoryParams{
+			FromDate: dayAsInteger(&from),
+			ToDate:   dayAsInteger(&to),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to find range statistics: %w for %s - %s", err, from.String(), to.String())
+		}
+		for _, value := range values {
+			rows = append(rows, rangeRow{Category: value.Category, Pending: value.Pending, Minutes: value.Minutes})
+		}
+	case model.GroupByTask:
+		values, err := self.queries.FindRangeStatisticsPerTask(ctx, FindRangeStatisticsPerTaskParams{
+			FromDate: dayAsInteger(&from),
+			ToDate:   dayAsInteger(&to),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to find range statistics: %w for %s - %s", err, from.String(), to.String())
+		}
+		for _, value := range values {
+			rows = append(rows, rangeRow{Category: value.Category, Task: value.Task, Pending: value.Pending, Minutes: value.Minutes})
+		}
+	default:
+		return nil, fmt.Errorf("unknown grouping %v", groupBy)
+	}
+
+	result := make([]model.RangeStatistic, 0, len(rows))
+	positions := make(map[[2]string]int, len(rows))
+	for _, row := range rows {
+		key := [2]string{row.Category, row.Task}
+		position, ok := positions[key]
+		if !ok {
+			position = len(result)
+			positions[key] = position
+			result = append(result, model.RangeStatistic{
+				Category: row.Category,
+				Task:     row.Task,
+				Overtime: self.overtime(model.CategoryType(row.Category)),
+			})
+		}
+		if row.Pending {
+			result[position].Dirty += model.Minutes(row.Minutes)
+		} else {
+			result[position].Total += model.Minutes(row.Minutes)
+		}
+	}
+	return result, nil
+}
+
+func (self *impl) RecentTasks(ctx context.Context, limit int) ([]string, error) {
+	tasks, err := self.queries.FindRecentTasks(ctx, int64(limit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find recent tasks: %w", err)
+	}
+	return tasks, nil
+}
+
+func (self *impl) FrequentComments(ctx context.Context, category string, task string, limit int) ([]string, error) {
+	var comments []string
+	var err error
+	if task != "" {
+		comments, err = self.queries.FindFrequentCommentsForTask(ctx, FindFrequentCommentsForTaskParams{Task: task, Limit: int64(limit)})
+	} else {
+		comments, err = self.queries.FindFrequentCommentsForCategory(ctx, FindFrequentCommentsForCategoryParams{Category: category, Limit: int64(limit)})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find frequent comments: %w", err)
+	}
+	return comments, nil
+}
+
+func (self *impl) TaskDays(ctx context.Context, task string) ([]model.Day, error) {
+	dates, err := self.queries.FindTaskDates(ctx, task)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find days of task: %w", err)
+	}
+	days := make([]model.Day, 0, len(dates))
+	for _, date := range dates {
+		day, err := integerAsDay(date)
+		if err != nil {
+			return nil, err
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+func (self *impl) MatchingDays(ctx context.Context, text string, limit int) ([]model.Day, error) {
+	dates, err := self.queries.FindMatchingDays(ctx, FindMatchingDaysParams{Text: text, Limit: int64(limit)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find matching days: %w", err)
+	}
+	days := make([]model.Day, 0, len(dates))
+	for _, date := range dates {
+		day, err := integerAsDay(date)
+		if err != nil {
+			return nil, err
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+func nameUse(name string, lastUsed int64) (model.NameUse, error) {
+	day, err := integerAsDay(lastUsed)
+	if err != nil {
+		return model.NameUse{}, err
+	}
+	return model.NameUse{Name: name, LastUsed: day}, nil
+}
+
+func (self *impl) MatchingTasks(ctx context.Context, text string, limit int) ([]model.NameUse, error) {
+	rows, err := self.queries.FindMatchingTasks(ctx, FindMatchingTasksParams{Text: text, Limit: int64(limit)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find matching tasks: %w", err)
+	}
+	uses := make([]model.NameUse, 0, len(rows))
+	for _, row := range rows {
+		use, err := nameUse(row.Task, row.LastUsed)
+		if err != nil {
+			return nil, err
+		}
+		uses = append(uses, use)
+	}
+	return uses, nil
+}
+
+func (self *impl) MatchingCategories(ctx context.Context, text string, limit int) ([]model.NameUse, error) {
+	rows, err := self.queries.FindMatchingCategories(ctx, FindMatchingCategoriesParams{Text: text, Limit: int64(limit)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find matching categories: %w", err)
+	}
+	uses := make([]model.NameUse, 0, len(rows))
+	for _, row := range rows {
+		use, err := nameUse(row.Category, row.LastUsed)
+		if err != nil {
+			return nil, err
+		}
+		uses = append(uses, use)
+	}
+	return uses, nil
+}
+
+func startMinute(r *model.TimeRange) sql.NullInt64 {
+	if r == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(r.StartMinute), Valid: true}
+}
+
+func endMinute(r *model.TimeRange) sql.NullInt64 {
+	if r == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(r.EndMinute), Valid: true}
+}
+
+func timeRange(start sql.NullInt64, end sql.NullInt64) *model.TimeRange {
+	if !start.Valid || !end.Valid {
+		return nil
+	}
+	return &model.TimeRange{StartMinute: uint16(start.Int64), EndMinute: uint16(end.Int64)}
+}
+
+func (self *impl) Leaves(ctx context.Context, from model.Day, to model.Day) ([]model.TakenLeave, error) {
+	values, err := self.queries.FindLeaves(ctx, FindLeavesParams{FromDate: dayAsInteger(&from), ToDate: dayAsInteger(&to)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find leaves: %w", err)
+	}
+	result := make([]model.TakenLeave, 0, len(values))
+	for _, value := range values {
+		day, err := integerAsDay(value.TimesheetDate)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, model.TakenLeave{
+			Date:  day,
+			Leave: model.Leave{Kind: value.Kind, Time: model.Minutes(value.Minutes), Comment: value.Comment},
 		})
 	}
+	return result, nil
+}
 
+func (self *impl) Ledger(ctx context.Context, knowsAboutMonth model.KnowsAboutMonth) ([]model.LedgerMonth, error) {
+	month := knowsAboutMonth.Month()
+	values, err := self.queries.FindBalances(ctx, dayAsInteger(&month.BeginDate)/100)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find balances: %w", err)
+	}
+	result := make([]model.LedgerMonth, 0, len(values))
+	for _, value := range values {
+		first, err := integerAsDay(value.Month*100 + 1)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, model.LedgerMonth{Month: first, Worked: model.Minutes(value.Worked)})
+	}
 	return result, nil
 }
 
@@ -316,3 +602,11 @@ func dayAsInteger(d *model.Day) int64 {
 	}
 	return int64(v)
 }
+
+func integerAsDay(value int64) (model.Day, error) {
+	parsed, err := time.Parse("20060102", strconv.FormatInt(value, 10))
+	if err != nil {
+		return model.Day{}, fmt.Errorf("invalid day %d: %w", value, err)
+	}
+	return model.Day(parsed), nil
+}
@@ -123,3 +123,36 @@ func TestShouldBeAbleToPresentDailyStatisticsOrderShouldNotMatter(t *testing.T)
 		}
 	})
 }
+
+func TestShouldKeepClockRangeOfEntry(t *testing.T) {
+	useDb(t, func(saver model.Saver, query model.Queryer) {
+		timesheet := model.TimesheetForDate(time.Now())
+
+		entry := model.TimesheetEntry{Hours: 1, Minutes: 30, Category: "work", Comment: "standup", Range: &model.TimeRange{StartMinute: 9*60 + 15, EndMinute: 10*60 + 45}}
+		if error := timesheet.AddEntry(entry); error != nil {
+			t.Errorf("Error adding entry: %v", error)
+		}
+		entry = model.TimesheetEntry{Hours: 1, Category: "work", Comment: "no range"}
+		if error := timesheet.AddEntry(entry); error != nil {
+			t.Errorf("Error adding entry: %v", error)
+		}
+		saveError := saver.Save(context.Background(), timesheet)
+		if saveError != nil {
+			t.Errorf("Error saving time sheet: %v", saveError)
+		}
+		entries, err := query.DaySummary(context.Background(), timesheet)
+		if err != nil {
+			t.Errorf("Error getting day summary: %v", err)
+		}
+		ranges := 0
+		for _, dayEntry := range entries {
+			if dayEntry.Comment == "standup" {
+				assert.Equal(t, &model.TimeRange{StartMinute: 9*60 + 15, EndMinute: 10*60 + 45}, dayEntry.Range)
+				ranges++
+			} else {
+				assert.Nil(t, dayEntry.Range)
+			}
+		}
+		assert.Equal(t, 2, ranges, "Expected saved and pending entry with range")
+	})
+}
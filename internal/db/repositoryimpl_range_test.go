@@ -0,0 +1,67 @@
+package db_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jborkows/timesheets/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func saveEntries(t *testing.T, date time.Time, saver model.Saver, entries ...model.TimesheetEntry) {
+	timesheet := model.TimesheetForDate(date)
+	for _, entry := range entries {
+		if error := timesheet.AddEntry(entry); error != nil {
+			assert.FailNow(t, "Error adding entry", error)
+		}
+	}
+	if saveError := saver.Save(context.Background(), timesheet); saveError != nil {
+		assert.FailNow(t, "Error saving time sheet", saveError)
+	}
+}
+
+func TestShouldSumUpRangeAcrossMonthsPerCategory(t *testing.T) {
+	useDb(t, func(saver model.Saver, query model.Queryer) {
+		task := "task-1"
+		saveEntries(t, time.Date(2025, time.January, 30, 0, 0, 0, 0, time.UTC), saver,
+			model.TimesheetEntry{Hours: 2, Minutes: 30, Category: "work", Task: &task},
+			model.TimesheetEntry{Hours: 1, Category: "meeting"})
+		saveEntries(t, time.Date(2025, time.February, 3, 0, 0, 0, 0, time.UTC), saver,
+			model.TimesheetEntry{Hours: 4, Minutes: 45, Category: "work"})
+		saveEntries(t, time.Date(2025, time.February, 20, 0, 0, 0, 0, time.UTC), saver,
+			model.TimesheetEntry{Hours: 8, Category: "work"})
+
+		from := model.Day(time.Date(2025, time.January, 15, 0, 0, 0, 0, time.UTC))
+		to := model.Day(time.Date(2025, time.February, 14, 0, 0, 0, 0, time.UTC))
+		statistics, err := query.Range(context.Background(), from, to, model.GroupByCategory)
+		if err != nil {
+			t.Errorf("Error getting range statistics: %v", err)
+		}
+		assert.Equal(t, []model.RangeStatistic{
+			{Category: "meeting", Dirty: 60, Total: 60},
+			{Category: "work", Dirty: 7*60 + 15, Total: 7*60 + 15},
+		}, statistics)
+	})
+}
+
+func TestShouldSumUpRangePerTask(t *testing.T) {
+	useDb(t, func(saver model.Saver, query model.Queryer) {
+		first := "task-1"
+		second := "task-2"
+		saveEntries(t, time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC), saver,
+			model.TimesheetEntry{Hours: 1, Category: "work", Task: &first},
+			model.TimesheetEntry{Hours: 2, Category: "work", Task: &second},
+			model.TimesheetEntry{Minutes: 30, Category: "work", Task: &first})
+
+		day := model.Day(time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC))
+		statistics, err := query.Range(context.Background(), day, day, model.GroupByTask)
+		if err != nil {
+			t.Errorf("Error getting range statistics: %v", err)
+		}
+		assert.Equal(t, []model.RangeStatistic{
+			{Category: "work", Task: "task-1", Dirty: 90, Total: 90},
+			{Category: "work", Task: "task-2", Dirty: 120, Total: 120},
+		}, statistics)
+	})
+}
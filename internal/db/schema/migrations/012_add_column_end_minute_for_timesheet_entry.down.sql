@@ -0,0 +1 @@
+alter table timesheet_entry_data drop column end_minute;
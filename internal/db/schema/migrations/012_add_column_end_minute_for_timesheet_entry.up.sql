@@ -0,0 +1 @@
+alter table timesheet_entry_data add column end_minute integer;
@@ -0,0 +1 @@
+alter table timesheet_entry_data add column overtime boolean not null default 0;
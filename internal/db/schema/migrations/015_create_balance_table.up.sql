@@ -0,0 +1,4 @@
+create table balance_data (
+    month integer primary key not null,
+    worked integer not null
+);
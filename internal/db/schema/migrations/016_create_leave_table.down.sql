@@ -0,0 +1 @@
+drop table leave_data;
@@ -0,0 +1,12 @@
+create table leave_data (
+    id integer primary key autoincrement,
+    timesheet_date integer not null,
+    kind text not null,
+    minutes integer not null,
+    comment text not null default '',
+    constraint fk_leave_timesheet
+        foreign key (timesheet_date)
+        references timesheet_data (date)
+        on delete cascade
+        on update cascade
+);
@@ -0,0 +1 @@
+drop view monthly_ongoing_report_data;
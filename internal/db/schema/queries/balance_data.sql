@@ -0,0 +1,9 @@
+-- name: SaveBalance :exec
+insert into balance_data (month, worked) values (:month, :worked)
+on conflict (month) do update set worked = excluded.worked;
+
+-- name: FindBalances :many
+select * from balance_data where month <= :month order by month;
+
+-- name: ClearBalances :exec
+delete from balance_data;
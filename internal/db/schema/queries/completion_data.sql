@@ -0,0 +1,23 @@
+-- name: FindRecentTasks :many
+select task
+from timesheet_entry_data
+where task != '' and pending = 0
+group by task
+order by max(timesheet_date) desc, task
+limit :limit;
+
+-- name: FindFrequentCommentsForTask :many
+select comment
+from timesheet_entry_data
+where task = :task and comment != '' and pending = 0
+group by comment
+order by count(*) desc, comment
+limit :limit;
+
+-- name: FindFrequentCommentsForCategory :many
+select comment
+from timesheet_entry_data
+where category = :category and task = '' and comment != '' and pending = 0
+group by comment
+order by count(*) desc, comment
+limit :limit;
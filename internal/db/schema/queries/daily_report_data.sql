@@ -5,12 +5,11 @@ select * from daily_report_data where date = :date;
 -- name: FindMonthlyStatistics :many
 select * from monthly_report_data where month = :date;
 
--- name: FindMonthlyStatisticsForCategories :many
-select * from monthly_report_data where month = :date and category in (sqlc.slice('categories'));;
+-- name: FindMonthlyRegularStatistics :many
+select * from monthly_report_data where month = :date and overtime = 0 and category in (sqlc.slice('categories'));
+
+-- name: FindMonthlyOvertimeStatistics :many
+select * from monthly_report_data where month = :date and (overtime = 1 or category in (sqlc.slice('categories')));
 
 -- name: FindWeeklyStatistics :many
 select * from weekly_report_data where week_begin_date = :start_date and week_end_date = :end_date;
-
--- name: FindMonthlyOngoingStatistics :one
-select counted_days from monthly_ongoing_report_data where month = :date and pending = :pending;
-
@@ -0,0 +1,10 @@
+-- name: AddLeave :exec
+insert into leave_data (timesheet_date, kind, minutes, comment) values (:timesheet_date, :kind, :minutes, :comment);
+
+-- name: ClearLeaves :exec
+delete from leave_data where timesheet_date = :timesheet_date;
+
+-- name: FindLeaves :many
+select timesheet_date, kind, minutes, comment from leave_data
+where timesheet_date between :from_date and :to_date
+order by timesheet_date, kind;
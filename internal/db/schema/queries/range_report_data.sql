@@ -0,0 +1,13 @@
+-- name: FindRangeStatisticsPerCategory :many
+select category, pending, cast(sum(hours * 60 + minutes) as integer) as minutes
+from timesheet_entry_data
+where timesheet_date between :from_date and :to_date and holiday = 0
+group by category, pending
+order by category;
+
+-- name: FindRangeStatisticsPerTask :many
+select category, task, pending, cast(sum(hours * 60 + minutes) as integer) as minutes
+from timesheet_entry_data
+where timesheet_date between :from_date and :to_date and holiday = 0
+group by category, task, pending
+order by category, task;
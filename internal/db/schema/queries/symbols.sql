@@ -0,0 +1,22 @@
+-- name: FindMatchingDays :many
+select date
+from timesheet_data
+where cast(date as text) like '%' || :text || '%'
+order by date desc
+limit :limit;
+
+-- name: FindMatchingTasks :many
+select task, cast(max(timesheet_date) as integer) as last_used
+from timesheet_entry_data
+where task != '' and task like '%' || :text || '%'
+group by task
+order by last_used desc, task
+limit :limit;
+
+-- name: FindMatchingCategories :many
+select category, cast(max(timesheet_date) as integer) as last_used
+from timesheet_entry_data
+where category != '' and category like '%' || :text || '%'
+group by category
+order by category
+limit :limit;
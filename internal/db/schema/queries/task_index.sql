@@ -0,0 +1,5 @@
+-- name: FindTaskDates :many
+select distinct timesheet_date
+from timesheet_entry_data
+where task = :task
+order by timesheet_date;
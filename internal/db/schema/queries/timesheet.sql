@@ -5,3 +5,9 @@ INSERT or IGNORE INTO timesheet_data (date,week_begin_date,week_end_date) VALUES
 SELECT * FROM timesheet_data WHERE date = (:date);
 
 
+
+-- name: ClearTimesheets :exec
+delete from timesheet_data;
+
+-- name: RemoveTimesheet :exec
+delete from timesheet_data where date = :date;
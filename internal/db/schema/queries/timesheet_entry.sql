@@ -9,10 +9,16 @@ delete from timesheet_entry_data where timesheet_date = :timesheet_date and pend
 insert into timesheet_entry_data (holiday, pending, hours, timesheet_date) values (:holiday, :pending, 8, :timesheet_date);
 
 -- name: AddEntry :exec
-insert into timesheet_entry_data (holiday, pending, timesheet_date, hours, minutes,comment,task, category) values (:holiday, :pending, :timesheet_date, :hours, :minutes, :comment, :task, :category);
+insert into timesheet_entry_data (holiday, pending, timesheet_date, hours, minutes,comment,task, category, start_minute, end_minute, overtime) values (:holiday, :pending, :timesheet_date, :hours, :minutes, :comment, :task, :category, :start_minute, :end_minute, :overtime);
 
 -- name: TimesheetForDay :many
-select holiday, pending, timesheet_date, hours, minutes,comment,task, category from timesheet_entry_data where timesheet_date = :timesheet_date
+select holiday, pending, timesheet_date, hours, minutes,comment,task, category, start_minute, end_minute from timesheet_entry_data where timesheet_date = :timesheet_date
 order by timesheet_date, category, task;
 
 
+
+-- name: RenameCategory :exec
+update timesheet_entry_data set category = :new_name where category = :old_name;
+
+-- name: RenameTask :exec
+update timesheet_entry_data set task = :new_name where task = :old_name;
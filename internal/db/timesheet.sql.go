@@ -9,6 +9,15 @@ import (
 	"context"
 )
 
+const clearTimesheets = `-- name: ClearTimesheets :exec
+delete from timesheet_data
+`
+
+func (q *Queries) ClearTimesheets(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, clearTimesheets)
+	return err
+}
+
 const createTimesheet = `-- name: CreateTimesheet :exec
 INSERT or IGNORE INTO timesheet_data (date,week_begin_date,week_end_date) VALUES (?1,?2,?3)
 `
@@ -34,3 +43,12 @@ func (q *Queries) FindTimesheet(ctx context.Context, date int64) (TimesheetDatum
 	err := row.Scan(&i.Date, &i.WeekBeginDate, &i.WeekEndDate)
 	return i, err
 }
+
+const removeTimesheet = `-- name: RemoveTimesheet :exec
+delete from timesheet_data where date = ?1
+`
+
+func (q *Queries) RemoveTimesheet(ctx context.Context, date int64) error {
+	_, err := q.db.ExecContext(ctx, removeTimesheet, date)
+	return err
+}
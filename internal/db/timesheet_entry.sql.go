@@ -7,10 +7,11 @@ package db
 
 import (
 	"context"
+	"database/sql"
 )
 
 const addEntry = `-- name: AddEntry :exec
-insert into timesheet_entry_data (holiday, pending, timesheet_date, hours, minutes,comment,task, category) values (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8)
+insert into timesheet_entry_data (holiday, pending, timesheet_date, hours, minutes,comment,task, category, start_minute, end_minute, overtime) values (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11)
 `
 
 type AddEntryParams struct {
@@ -22,6 +23,9 @@ type AddEntryParams struct {
 	Comment       string
 	Task          string
 	Category      string
+	StartMinute   sql.NullInt64
+	EndMinute     sql.NullInt64
+	Overtime      bool
 }
 
 func (q *Queries) AddEntry(ctx context.Context, arg AddEntryParams) error {
@@ -34,6 +38,9 @@ func (q *Queries) AddEntry(ctx context.Context, arg AddEntryParams) error {
 		arg.Comment,
 		arg.Task,
 		arg.Category,
+		arg.StartMinute,
+		arg.EndMinute,
+		arg.Overtime,
 	)
 	return err
 }
@@ -71,8 +78,36 @@ func (q *Queries) ClearTimesheetData(ctx context.Context, timesheetDate int64) e
 	return err
 }
 
+const renameCategory = `-- name: RenameCategory :exec
+update timesheet_entry_data set category = ?1 where category = ?2
+`
+
+type RenameCategoryParams struct {
+	NewName string
+	OldName string
+}
+
+func (q *Queries) RenameCategory(ctx context.Context, arg RenameCategoryParams) error {
+	_, err := q.db.ExecContext(ctx, renameCategory, arg.NewName, arg.OldName)
+	return err
+}
+
+const renameTask = `-- name: RenameTask :exec
+update timesheet_entry_data set task = ?1 where task = ?2
+`
+
+type RenameTaskParams struct {
+	NewName string
+	OldName string
+}
+
+func (q *Queries) RenameTask(ctx context.Context, arg RenameTaskParams) error {
+	_, err := q.db.ExecContext(ctx, renameTask, arg.NewName, arg.OldName)
+	return err
+}
+
 const timesheetForDay = `-- name: TimesheetForDay :many
-select holiday, pending, timesheet_date, hours, minutes,comment,task, category from timesheet_entry_data where timesheet_date = ?1
+select holiday, pending, timesheet_date, hours, minutes,comment,task, category, start_minute, end_minute from timesheet_entry_data where timesheet_date = ?1
 order by timesheet_date, category, task
 `
 
@@ -85,6 +120,8 @@ type TimesheetForDayRow struct {
 	Comment       string
 	Task          string
 	Category      string
+	StartMinute   sql.NullInt64
+	EndMinute     sql.NullInt64
 }
 
 func (q *Queries) TimesheetForDay(ctx context.Context, timesheetDate int64) ([]TimesheetForDayRow, error) {
@@ -105,6 +142,8 @@ func (q *Queries) TimesheetForDay(ctx context.Context, timesheetDate int64) ([]T
 			&i.Comment,
 			&i.Task,
 			&i.Category,
+			&i.StartMinute,
+			&i.EndMinute,
 		); err != nil {
 			return nil, err
 		}
@@ -4,13 +4,15 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sync/atomic"
+
 	"github.com/jborkows/timesheets/internal/model"
 )
 
 type TransactionSupport struct {
 	queries *Queries
 	db      *sql.DB
-	config  *model.Config
+	config  atomic.Pointer[model.Config]
 }
 
 func NewTransactionSupport(db *sql.DB) *TransactionSupport {
@@ -19,19 +21,24 @@ func NewTransactionSupport(db *sql.DB) *TransactionSupport {
 	return &TransactionSupport{
 		queries: dbTx,
 		db:      db,
-		config:  nil,
 	}
 }
 
 func CreateRepository(db *sql.DB, config *model.Config) model.Repository {
 	dbTx := New(db)
 
-	return &TransactionSupport{
+	support := &TransactionSupport{
 		queries: dbTx,
 		db:      db,
-		config:  config,
 	}
+	support.config.Store(config)
+	return support
 }
+
+func (support *TransactionSupport) UseConfig(config *model.Config) {
+	support.config.Store(config)
+}
+
 func (support *TransactionSupport) WithTransaction(ctx context.Context, operation func(context.Context, *Queries) error) error {
 	tx, err := support.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -55,7 +62,8 @@ func (support *TransactionSupport) WithTransaction(ctx context.Context, operatio
 
 func (support *TransactionSupport) Transactional(ctx context.Context, operation func(context.Context, model.Saver, model.Queryer) error) error {
 	err := support.WithTransaction(ctx, func(ctx context.Context, q *Queries) error {
-		repository := Repository(q, support.config.IsOvertime)
+		config := support.config.Load()
+		repository := Repository(q, config.IsOvertime, config.Week())
 		return operation(ctx, repository, repository)
 	})
 	if err != nil {
@@ -0,0 +1,43 @@
+package lspmessages
+
+// ApplyWorkspaceEditRequest is sent from server to client, see
+// https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#workspace_applyEdit
+type ApplyWorkspaceEditRequest struct {
+	Request
+	Params ApplyWorkspaceEditParams `json:"params"`
+}
+
+type ApplyWorkspaceEditParams struct {
+	Label string        `json:"label,omitempty"`
+	Edit  WorkspaceEdit `json:"edit"`
+}
+
+type ApplyWorkspaceEditResult struct {
+	Applied       bool   `json:"applied"`
+	FailureReason string `json:"failureReason,omitempty"`
+}
+
+type CreateFileOptions struct {
+	Overwrite      bool `json:"overwrite,omitempty"`
+	IgnoreIfExists bool `json:"ignoreIfExists,omitempty"`
+}
+
+type CreateFile struct {
+	Kind    string            `json:"kind"`
+	URI     string            `json:"uri"`
+	Options CreateFileOptions `json:"options"`
+}
+
+func NewCreateFile(uri string, options CreateFileOptions) CreateFile {
+	return CreateFile{Kind: "create", URI: uri, Options: options}
+}
+
+type OptionalVersionedTextDocumentIdentifier struct {
+	URI     string `json:"uri"`
+	Version *int   `json:"version"`
+}
+
+type TextDocumentEdit struct {
+	TextDocument OptionalVersionedTextDocumentIdentifier `json:"textDocument"`
+	Edits        []TextEdit                              `json:"edits"`
+}
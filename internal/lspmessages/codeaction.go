@@ -17,18 +17,25 @@ type TextDocumentCodeActionResponse struct {
 }
 
 type CodeActionContext struct {
-	// Add fields for CodeActionContext as needed
+	Diagnostics []Diagnostic `json:"diagnostics"`
 }
 
+const (
+	QuickFix     = "quickfix"
+	SourceAction = "source"
+)
+
 type CodeAction struct {
-	Title   string         `json:"title"`
-	Edit    *WorkspaceEdit `json:"edit,omitempty"`
-	Kind    string         `json:"kind,omitempty"`
-	Command *Command       `json:"command,omitempty"`
+	Title       string         `json:"title"`
+	Edit        *WorkspaceEdit `json:"edit,omitempty"`
+	Kind        string         `json:"kind,omitempty"`
+	Diagnostics []Diagnostic   `json:"diagnostics,omitempty"`
+	IsPreferred bool           `json:"isPreferred,omitempty"`
+	Command     *Command       `json:"command,omitempty"`
 }
 
 type Command struct {
 	Title     string `json:"title"`
 	Command   string `json:"command"`
-	Arguments string `json:"arguments,omitempty"`
+	Arguments []any  `json:"arguments,omitempty"`
 }
@@ -14,8 +14,18 @@ type CompletionResponse struct {
 	Result []CompletionItem `json:"result"`
 }
 
+// Kinds of completion items as numbered by LSP.
+const (
+	TextCompletion      = 1
+	ValueCompletion     = 12
+	EnumCompletion      = 13
+	ReferenceCompletion = 18
+)
+
 type CompletionItem struct {
-	Label         string `json:"label"`
-	Detail        string `json:"detail"`
-	Documentation string `json:"documentation"`
+	Label         string    `json:"label"`
+	Kind          int       `json:"kind,omitempty"`
+	Detail        string    `json:"detail"`
+	Documentation string    `json:"documentation"`
+	TextEdit      *TextEdit `json:"textEdit,omitempty"`
 }
@@ -1,5 +1,30 @@
 package lspmessages
 
+// ShowReportCommand opens report of period ("daily", "weekly" or "monthly") for file given by URI, arguments: [uri, period].
+const ShowReportCommand = "timesheets.showReport"
+
+// OpenTodayCommand opens file of today, creating it from template when missing, no arguments.
+const OpenTodayCommand = "timesheets.openToday"
+
+// CreateDayCommand opens file of the day, creating it from template when missing, arguments: [date as YYYY-MM-DD].
+const CreateDayCommand = "timesheets.createDay"
+
+// CreateNextDayCommand opens file of the day after the one given by URI, creating it from template when missing, arguments: [uri].
+const CreateNextDayCommand = "timesheets.createNextDay"
+
+// MonthReportCommand opens report of the month of file given by URI or of current month, arguments: [] or [uri].
+const MonthReportCommand = "timesheets.monthReport"
+
+// ReindexCommand rebuilds database from files, no arguments, returns ReindexResult.
+const ReindexCommand = "timesheets.reindex"
+
+// ExportMonthCommand writes report of the month of file given by URI into exports directory, arguments: [uri] or [uri, format], returns ExportResult.
+const ExportMonthCommand = "timesheets.exportMonth"
+
+func Commands() []string {
+	return []string{ShowReportCommand, OpenTodayCommand, CreateDayCommand, CreateNextDayCommand, MonthReportCommand, ReindexCommand, ExportMonthCommand}
+}
+
 type ExecuteCommandRequest struct {
 	Request
 	Params ExecuteCommandParams `json:"params"`
@@ -7,5 +32,19 @@ type ExecuteCommandRequest struct {
 
 type ExecuteCommandParams struct {
 	Command   string `json:"command"`
-	Arguments string `json:"arguments"`
+	Arguments []any  `json:"arguments"`
+}
+
+type ExecuteCommandResponse struct {
+	Response
+	Result any `json:"result"`
+}
+
+type ReindexResult struct {
+	Files  int `json:"files"`
+	Failed int `json:"failed"`
+}
+
+type ExportResult struct {
+	URI string `json:"uri"`
 }
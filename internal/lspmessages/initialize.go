@@ -8,10 +8,39 @@ type InitializeRequest struct {
 }
 
 type InitializeRequestParams struct {
-	ClientInfo *ClientInfo `json:"clientInfo"`
+	ClientInfo   *ClientInfo        `json:"clientInfo"`
+	Capabilities ClientCapabilities `json:"capabilities"`
 	// ... there's tons more that goes here
 }
 
+type ClientCapabilities struct {
+	Workspace *WorkspaceClientCapabilities `json:"workspace"`
+}
+
+type WorkspaceClientCapabilities struct {
+	TextDocumentContent   *TextDocumentContentClientCapabilities   `json:"textDocumentContent"`
+	DidChangeWatchedFiles *DidChangeWatchedFilesClientCapabilities `json:"didChangeWatchedFiles"`
+}
+
+type DidChangeWatchedFilesClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration"`
+}
+
+type TextDocumentContentClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration"`
+}
+
+// SupportsTextDocumentContent tells whether client can show virtual documents served by workspace/textDocumentContent.
+func (params *InitializeRequestParams) SupportsTextDocumentContent() bool {
+	return params.Capabilities.Workspace != nil && params.Capabilities.Workspace.TextDocumentContent != nil
+}
+
+// SupportsWatchedFilesRegistration tells whether client accepts file watchers registered with client/registerCapability.
+func (params *InitializeRequestParams) SupportsWatchedFilesRegistration() bool {
+	workspace := params.Capabilities.Workspace
+	return workspace != nil && workspace.DidChangeWatchedFiles != nil && workspace.DidChangeWatchedFiles.DynamicRegistration
+}
+
 type ClientInfo struct {
 	Name    string `json:"name"`
 	Version string `json:"version"`
@@ -45,11 +74,20 @@ type ServerCapabilities struct {
 	HoverProvider              bool                             `json:"hoverProvider"`
 	DefinitionProvider         bool                             `json:"definitionProvider"`
 	CodeActionProvider         bool                             `json:"codeActionProvider"`
+	InlayHintProvider          bool                             `json:"inlayHintProvider"`
+	ReferencesProvider         bool                             `json:"referencesProvider"`
+	DocumentHighlightProvider  bool                             `json:"documentHighlightProvider"`
+	RenameProvider             *RenameOptions                   `json:"renameProvider,omitempty"`
+	WorkspaceSymbolProvider    bool                             `json:"workspaceSymbolProvider"`
+	DocumentSymbolProvider     bool                             `json:"documentSymbolProvider"`
+	FoldingRangeProvider       bool                             `json:"foldingRangeProvider"`
 	CompletionProvider         map[string]any                   `json:"completionProvider"`
-	ExecuteCommand             ExecuteCommandClientCapabilities `json:"executeCommand"`
+	ExecuteCommandProvider     ExecuteCommandClientCapabilities `json:"executeCommandProvider"`
+	CodeLensProvider           *CodeLensOptions                 `json:"codeLensProvider,omitempty"`
 	ColorProvider              bool                             `json:"colorProvider"`
 	DocumentFormattingProvider bool                             `json:"documentFormattingProvider"`
 	SemanticTokensProvider     SemanticTokensOptions            `json:"semanticTokensProvider"`
+	Workspace                  *WorkspaceServerCapabilities     `json:"workspace,omitempty"`
 }
 
 type SemanticTokensOptions struct {
@@ -81,7 +119,14 @@ func NewInitializeResponse(response Response) InitializeResponse {
 				HoverProvider:              true,
 				DefinitionProvider:         true,
 				DocumentFormattingProvider: true,
-				// CodeActionProvider: true,
+				CodeActionProvider:         true,
+				InlayHintProvider:          true,
+				ReferencesProvider:         true,
+				DocumentHighlightProvider:  true,
+				RenameProvider:             &RenameOptions{PrepareProvider: true},
+				WorkspaceSymbolProvider:    true,
+				DocumentSymbolProvider:     true,
+				FoldingRangeProvider:       true,
 				// ColorProvider:      true,
 				CompletionProvider: map[string]any{},
 				SemanticTokensProvider: SemanticTokensOptions{
@@ -92,10 +137,13 @@ func NewInitializeResponse(response Response) InitializeResponse {
 					Range: false,
 					Full:  true,
 				},
-
-				// ExecuteCommand: ExecuteCommandClientCapabilities{
-				// 	Commands: []string{"some_command"},
-				// },
+				CodeLensProvider: &CodeLensOptions{ResolveProvider: false},
+				ExecuteCommandProvider: ExecuteCommandClientCapabilities{
+					Commands: Commands(),
+				},
+				Workspace: &WorkspaceServerCapabilities{
+					TextDocumentContent: &TextDocumentContentOptions{Schemes: []string{model.ReportScheme}},
+				},
 			},
 			ServerInfo: ServerInfo{
 				Name:    "timesheets",
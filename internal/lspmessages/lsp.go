@@ -1,5 +1,7 @@
 package lspmessages
 
+import "encoding/json"
+
 type Request struct {
 	RPC    string `json:"jsonrpc"`
 	ID     int    `json:"id"`
@@ -18,3 +20,24 @@ type Notification struct {
 	RPC    string `json:"jsonrpc"`
 	Method string `json:"method"`
 }
+
+// RequestFailed is LSP error code of request which was valid but could not be fulfilled.
+const RequestFailed = -32803
+
+type ResponseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// ErrorResponse answers request which failed, it has no result.
+type ErrorResponse struct {
+	Response
+	Error *ResponseError `json:"error"`
+}
+
+// ResponseMessage is answer of client to request sent by server, Result is decoded by the one waiting for it.
+type ResponseMessage struct {
+	Response
+	Result json.RawMessage `json:"result"`
+	Error  *ResponseError  `json:"error"`
+}
@@ -0,0 +1,37 @@
+package lspmessages
+
+type ReferencesRequest struct {
+	Request
+	Params ReferenceParams `json:"params"`
+}
+
+type ReferenceParams struct {
+	TextDocumentPositionParams
+	Context ReferenceContext `json:"context"`
+}
+
+type ReferenceContext struct {
+	IncludeDeclaration bool `json:"includeDeclaration"`
+}
+
+type ReferencesResponse struct {
+	Response
+	Result []Location `json:"result"`
+}
+
+type DocumentHighlightRequest struct {
+	Request
+	Params TextDocumentPositionParams `json:"params"`
+}
+
+type DocumentHighlightResponse struct {
+	Response
+	Result []DocumentHighlight `json:"result"`
+}
+
+const TextHighlight = 1
+
+type DocumentHighlight struct {
+	Range Range `json:"range"`
+	Kind  int   `json:"kind,omitempty"`
+}
@@ -0,0 +1,36 @@
+package lspmessages
+
+type PrepareRenameRequest struct {
+	Request
+	Params TextDocumentPositionParams `json:"params"`
+}
+
+type PrepareRenameResponse struct {
+	Response
+	Result *PrepareRenameResult `json:"result"`
+}
+
+type PrepareRenameResult struct {
+	Range       Range  `json:"range"`
+	Placeholder string `json:"placeholder"`
+}
+
+type RenameRequest struct {
+	Request
+	Params RenameParams `json:"params"`
+}
+
+type RenameParams struct {
+	TextDocumentPositionParams
+	NewName string `json:"newName"`
+}
+
+type RenameResponse struct {
+	Response
+	Result *WorkspaceEdit `json:"result,omitempty"`
+	Error  *ResponseError `json:"error,omitempty"`
+}
+
+type RenameOptions struct {
+	PrepareProvider bool `json:"prepareProvider"`
+}
@@ -0,0 +1,14 @@
+package lspmessages
+
+// ShowDocumentRequest is sent from server to client, see
+// https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#window_showDocument
+type ShowDocumentRequest struct {
+	Request
+	Params ShowDocumentParams `json:"params"`
+}
+
+type ShowDocumentParams struct {
+	URI       string `json:"uri"`
+	External  bool   `json:"external,omitempty"`
+	TakeFocus bool   `json:"takeFocus,omitempty"`
+}
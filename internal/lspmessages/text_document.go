@@ -52,7 +52,9 @@ type Range struct {
 	End   Position `json:"end"`
 }
 type WorkspaceEdit struct {
-	Changes map[string][]TextEdit `json:"changes"`
+	Changes map[string][]TextEdit `json:"changes,omitempty"`
+	// DocumentChanges hold CreateFile and TextDocumentEdit operations, applied in order
+	DocumentChanges []any `json:"documentChanges,omitempty"`
 }
 
 type TextEdit struct {
@@ -0,0 +1,35 @@
+package lspmessages
+
+// TextDocumentContentRequest asks for content of a virtual document, see
+// https://microsoft.github.io/language-server-protocol/specifications/lsp/3.18/specification/#workspace_textDocumentContent
+type TextDocumentContentRequest struct {
+	Request
+	Params TextDocumentContentParams `json:"params"`
+}
+
+type TextDocumentContentParams struct {
+	URI string `json:"uri"`
+}
+
+type TextDocumentContentResponse struct {
+	Response
+	Result TextDocumentContentResult `json:"result"`
+}
+
+type TextDocumentContentResult struct {
+	Text string `json:"text"`
+}
+
+// TextDocumentContentRefreshRequest is sent from server to client when virtual document changed.
+type TextDocumentContentRefreshRequest struct {
+	Request
+	Params TextDocumentContentParams `json:"params"`
+}
+
+type TextDocumentContentOptions struct {
+	Schemes []string `json:"schemes"`
+}
+
+type WorkspaceServerCapabilities struct {
+	TextDocumentContent *TextDocumentContentOptions `json:"textDocumentContent,omitempty"`
+}
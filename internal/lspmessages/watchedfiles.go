@@ -0,0 +1,55 @@
+package lspmessages
+
+type FileChangeType int
+
+const (
+	FileCreated FileChangeType = 1
+	FileChanged FileChangeType = 2
+	FileDeleted FileChangeType = 3
+)
+
+type DidChangeWatchedFilesNotification struct {
+	Notification
+	Params DidChangeWatchedFilesParams `json:"params"`
+}
+
+type DidChangeWatchedFilesParams struct {
+	Changes []FileEvent `json:"changes"`
+}
+
+type FileEvent struct {
+	URI  string         `json:"uri"`
+	Type FileChangeType `json:"type"`
+}
+
+// FileSystemWatcher watches files matching GlobPattern, which is either a text or RelativePattern.
+type FileSystemWatcher struct {
+	GlobPattern any `json:"globPattern"`
+}
+
+// RelativePattern matches files relative to BaseURI, e.g. ones outside of workspace.
+type RelativePattern struct {
+	BaseURI string `json:"baseUri"`
+	Pattern string `json:"pattern"`
+}
+
+type DidChangeWatchedFilesRegistrationOptions struct {
+	Watchers []FileSystemWatcher `json:"watchers"`
+}
+
+// RegisterCapabilityRequest is sent from server to client, see
+// https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#client_registerCapability
+type RegisterCapabilityRequest struct {
+	Request
+	Params RegistrationParams `json:"params"`
+}
+
+type RegistrationParams struct {
+	Registrations []Registration `json:"registrations"`
+}
+
+type Registration struct {
+	ID              string `json:"id"`
+	Method          string `json:"method"`
+	RegisterOptions any    `json:"registerOptions,omitempty"`
+}
@@ -0,0 +1,95 @@
+package lspserver
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	messages "github.com/jborkows/timesheets/internal/lspmessages"
+)
+
+func (self *Controller) CodeAction(request *messages.CodeActionRequest) error {
+	params := request.Params
+	actions := []messages.CodeAction{}
+	date, err := self.service.ParseDateFromName(params.TextDocument.URI)
+	if err != nil {
+		msg := messages.TextDocumentCodeActionResponse{
+			Response: response(request.Request),
+			Result:   actions,
+		}
+		return self.writeResponse(msg)
+	}
+
+	content := self.content.get(params.TextDocument.URI)
+	for lineNumber := params.Range.Start.Line; lineNumber <= params.Range.End.Line && lineNumber < len(content); lineNumber++ {
+		actions = append(actions, self.categoryFixes(params, content[lineNumber], lineNumber, date)...)
+	}
+	if action := self.fillRecurring(params.TextDocument.URI, content, date); action != nil {
+		actions = append(actions, *action)
+	}
+
+	msg := messages.TextDocumentCodeActionResponse{
+		Response: response(request.Request),
+		Result:   actions,
+	}
+	return self.writeResponse(msg)
+}
+
+func (self *Controller) categoryFixes(params messages.TextDocumentCodeActionParams, line string, lineNumber int, date time.Time) []messages.CodeAction {
+	fix := self.service.CategoryFix(line, date)
+	if fix == nil {
+		return nil
+	}
+	var diagnostics []messages.Diagnostic
+	for _, diagnostic := range params.Context.Diagnostics {
+		if diagnostic.Range.Start.Line == lineNumber {
+			diagnostics = append(diagnostics, diagnostic)
+		}
+	}
+	actions := make([]messages.CodeAction, 0, len(fix.Suggestions))
+	for i, suggestion := range fix.Suggestions {
+		edit := messages.TextEdit{
+			Range: messages.Range{
+				Start: messages.Position{Line: lineNumber, Character: fix.Column},
+				End:   messages.Position{Line: lineNumber, Character: fix.Column + fix.Length},
+			},
+			NewText: suggestion,
+		}
+		actions = append(actions, messages.CodeAction{
+			Title: fmt.Sprintf("Replace '%s' with '%s'", fix.Category, suggestion),
+			Kind:  messages.QuickFix,
+			Edit: &messages.WorkspaceEdit{
+				Changes: map[string][]messages.TextEdit{
+					params.TextDocument.URI: {edit},
+				},
+			},
+			Diagnostics: diagnostics,
+			IsPreferred: i == 0,
+		})
+	}
+	return actions
+}
+
+// fillRecurring appends recurring entries of the day which are missing in the document.
+func (self *Controller) fillRecurring(uri string, content []string, date time.Time) *messages.CodeAction {
+	missing := self.service.MissingRecurring(content, date)
+	if len(missing) == 0 {
+		return nil
+	}
+	edit := messages.TextEdit{NewText: strings.Join(missing, "\n") + "\n"}
+	if len(content) > 0 {
+		end := messages.Position{Line: len(content) - 1, Character: utf8.RuneCountInString(content[len(content)-1])}
+		edit = messages.TextEdit{
+			Range:   messages.Range{Start: end, End: end},
+			NewText: "\n" + strings.Join(missing, "\n"),
+		}
+	}
+	return &messages.CodeAction{
+		Title: fmt.Sprintf("Fill %d missing recurring entries", len(missing)),
+		Kind:  messages.SourceAction,
+		Edit: &messages.WorkspaceEdit{
+			Changes: map[string][]messages.TextEdit{uri: {edit}},
+		},
+	}
+}
@@ -0,0 +1,82 @@
+package lspserver
+
+import (
+	"fmt"
+	"log"
+
+	messages "github.com/jborkows/timesheets/internal/lspmessages"
+	"github.com/jborkows/timesheets/internal/model"
+)
+
+func (self *Controller) CodeLens(request *messages.CodeLensRequest) error {
+	lenses := []messages.CodeLens{}
+	uri := request.Params.TextDocument.URI
+	date, err := self.service.ParseDateFromName(uri)
+	if err == nil {
+		summary, err := self.service.Summary(date)
+		if err != nil {
+			log.Printf("Error getting summary for %s: %s", uri, err)
+		} else {
+			lenses = summaryLenses(uri, summary)
+		}
+	}
+	msg := messages.CodeLensResponse{
+		Response: response(request.Request),
+		Result:   lenses,
+	}
+	return self.writeResponse(msg)
+}
+
+func summaryLenses(uri string, summary *model.Summary) []messages.CodeLens {
+	top := messages.Range{
+		Start: messages.Position{Line: 0, Character: 0},
+		End:   messages.Position{Line: 0, Character: 0},
+	}
+	periods := []struct {
+		title   string
+		kind    model.SectionKind
+		summary model.PeriodSummary
+	}{
+		{"Day", model.DaySection, summary.Day},
+		{"Week", model.WeekSection, summary.Week},
+		{"Month", model.MonthSection, summary.Month},
+	}
+	lenses := make([]messages.CodeLens, 0, len(periods))
+	for _, period := range periods {
+		title := fmt.Sprintf("%s %s/%sh", period.title, shortHours(period.summary.Worked), shortHours(period.summary.Expected))
+		if period.kind == model.MonthSection {
+			title += fmt.Sprintf(" (%sh to date)", shortHours(period.summary.ExpectedToDate))
+		}
+		lenses = append(lenses, messages.CodeLens{
+			Range: top,
+			Command: &messages.Command{
+				Title:     title,
+				Command:   messages.ShowReportCommand,
+				Arguments: []any{uri, period.kind.String()},
+			},
+		})
+	}
+	if summary.Balance != nil {
+		lenses = append(lenses, messages.CodeLens{
+			Range: top,
+			Command: &messages.Command{
+				Title:     fmt.Sprintf("Balance %sh", summary.Balance),
+				Command:   messages.ShowReportCommand,
+				Arguments: []any{uri, model.MonthSection.String()},
+			},
+		})
+	}
+	return lenses
+}
+
+// requestCodeLensRefresh asks client to re-request lenses, as totals change when any file is saved.
+func (self *Controller) requestCodeLensRefresh() {
+	message := messages.Request{
+		RPC:    "2.0",
+		ID:     self.nextRequestID(),
+		Method: "workspace/codeLens/refresh",
+	}
+	if err := self.writeResponse(message); err != nil {
+		log.Printf("Error requesting code lens refresh: %s", err)
+	}
+}
@@ -0,0 +1,58 @@
+package lspserver
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jborkows/timesheets/internal/model"
+)
+
+// configFile is absolute path and URI of config file, ok is false when server was started without it.
+func (self *Controller) configFile() (string, string, bool) {
+	if self.configPath == "" {
+		return "", "", false
+	}
+	path, err := filepath.Abs(self.configPath)
+	if err != nil {
+		log.Printf("Error resolving config path %s: %s", self.configPath, err)
+		return "", "", false
+	}
+	return path, model.FileURI(path), true
+}
+
+// reloadConfig switches to changed config file, e.g. edited by hand. Days are reindexed as they depend
+// on configuration, open documents are kept as drafts. Config saved after rename is already in use.
+func (self *Controller) reloadConfig(path string) bool {
+	file, err := os.Open(path)
+	if err != nil {
+		log.Printf("Error opening changed config %s: %s", path, err)
+		return false
+	}
+	defer file.Close()
+	config, err := model.ReadConfig(file)
+	if err != nil {
+		log.Printf("Error reading changed config %s: %s", path, err)
+		return false
+	}
+	result, err := self.service.ReplaceConfig(config)
+	if err != nil {
+		log.Printf("Error reindexing with changed config %s: %s", path, err)
+		return false
+	}
+	if result == nil {
+		log.Printf("Config %s is already in use", path)
+		return false
+	}
+	log.Printf("Reloaded config %s, reindexed %d files", path, result.Files)
+	for _, uri := range self.content.uris() {
+		date, err := self.service.ParseDateFromName(uri)
+		if err != nil {
+			continue
+		}
+		_, errors := self.service.ProcessForDraft(strings.Join(self.content.get(uri), "\n"), date)
+		self.notifyAboutErrors(errors, uri)
+	}
+	return true
+}
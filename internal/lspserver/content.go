@@ -23,3 +23,21 @@ func (c *content) put(uri string, lines []string) {
 func (c *content) get(uri string) []string {
 	return c.fileLines[uri]
 }
+
+// isOpen tells whether the document is open in the editor, which then owns its content.
+func (c *content) isOpen(uri string) bool {
+	_, ok := c.fileLines[uri]
+	return ok
+}
+
+func (c *content) uris() []string {
+	uris := make([]string, 0, len(c.fileLines))
+	for uri := range c.fileLines {
+		uris = append(uris, uri)
+	}
+	return uris
+}
+
+func (c *content) remove(uri string) {
+	delete(c.fileLines, uri)
+}
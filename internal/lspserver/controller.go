@@ -1,11 +1,12 @@
 package lspserver
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"log"
-	"net/url"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	messages "github.com/jborkows/timesheets/internal/lspmessages"
@@ -16,6 +17,8 @@ import (
 type ControllerConfig struct {
 	Service *model.Service
 	Writer  io.Writer
+	// ConfigPath is changed when categories are renamed, may be empty
+	ConfigPath string
 }
 
 type Controller struct {
@@ -24,6 +27,12 @@ type Controller struct {
 	service          *model.Service
 	writer           io.Writer
 	content          *content
+	requestID        atomic.Int64
+	reports          *shownReports
+	pending          *pendingRequests
+	virtualReports   bool
+	watchedFiles     bool
+	configPath       string
 }
 
 func NewController(c *ControllerConfig) *Controller {
@@ -34,6 +43,9 @@ func NewController(c *ControllerConfig) *Controller {
 		didChangeReactor: model.Debounce2(reactOnChange, time.Duration(1)*time.Second),
 		didSaveReactor:   model.Debounce2(reactOnSave, time.Duration(1)*time.Second),
 		content:          newContent(),
+		reports:          newShownReports(),
+		pending:          newPendingRequests(),
+		configPath:       c.ConfigPath,
 	}
 }
 
@@ -56,6 +68,8 @@ func reactOnChange(msg *messages.TextDocumentDidChangeNotification, c *Controlle
 	}
 	_, errors := c.service.ProcessForDraft(text, date)
 	c.notifyAboutErrors(errors, msg.Params.TextDocument.URI)
+	c.requestCodeLensRefresh()
+	c.requestReportsRefresh()
 	log.Println("Received didChange notification: ", msg.Params.TextDocument.URI, "representing", date)
 }
 
@@ -68,6 +82,8 @@ func reactOnSave(msg *messages.DidSaveTextDocumentNotification, c *Controller) {
 	_, errors := c.service.ProcessForSave(text, date)
 	c.notifyAboutErrors(errors, msg.Params.TextDocument.URI)
 	c.requestSemanticTokensRefresh()
+	c.requestCodeLensRefresh()
+	c.requestReportsRefresh()
 	log.Println("Received didSave notification: ", msg.Params.TextDocument.URI)
 }
 
@@ -75,6 +91,11 @@ func (self *Controller) onOpen(msg *messages.DidOpenTextDocumentNotification) {
 	self.changeContent(msg.Params.TextDocument)
 }
 
+func (self *Controller) onClose(msg *messages.DidCloseTextDocumentNotification) {
+	self.content.remove(msg.Params.TextDocument.URI)
+	self.reports.remove(msg.Params.TextDocument.URI)
+}
+
 func (self *Controller) changeContent(textDocument messages.TextDocumentItem) {
 	self.content.put(textDocument.URI, strings.Split(textDocument.Text, "\n"))
 }
@@ -84,23 +105,23 @@ func (self *Controller) notifyAboutErrors(params []model.LineError, uri string)
 	for _, param := range params {
 
 		var errorMessage string
-		switch param.Err {
-		case model.ErrEmptyLine:
+		switch {
+		case errors.Is(param.Err, model.ErrEmptyLine):
 			continue
-		case model.ErrInvalidCategory:
+		case errors.Is(param.Err, model.ErrInvalidCategory):
 			errorMessage = "Invalid category. Possible categories: " + strings.Join(self.service.PossibleCategories(), ", ")
-		case model.ErrInvalidTime:
-			errorMessage = "Invalid time format. Use X.Y or XhYm (e.g., 1.5 or 1h30m)"
 		default:
 			errorMessage = param.Err.Error()
-			log.Printf("Unknown error: %v", param)
+			if param.Severity == model.SeverityError {
+				log.Printf("Unknown error: %v", param)
+			}
 		}
 		diagnostic := messages.Diagnostic{
 			Message:  errorMessage,
-			Severity: 1,
+			Severity: int(param.Severity),
 			Range: messages.Range{
-				Start: messages.Position{Line: param.LineNumber, Character: 0},
-				End:   messages.Position{Line: param.LineNumber, Character: 0},
+				Start: messages.Position{Line: param.LineNumber, Character: param.Start},
+				End:   messages.Position{Line: param.LineNumber, Character: param.End},
 			},
 		}
 		diagnostics = append(diagnostics, diagnostic)
@@ -135,19 +156,41 @@ func (self *Controller) completion(request *messages.CompletionRequest) error {
 }
 
 func (self *Controller) completions(uri string, position messages.Position) []messages.CompletionItem {
-	if position.Character > 5 {
-		return []messages.CompletionItem{}
+	line := ""
+	if content := self.content.get(uri); position.Line < len(content) {
+		line = content[position.Line]
 	}
 	completions := []messages.CompletionItem{}
-	for _, category := range self.service.PossibleCategories() {
+	for _, completion := range self.service.Completions(line, position.Character) {
 		completions = append(completions, messages.CompletionItem{
-			Label:  category,
-			Detail: "Category",
+			Label:  completion.Label,
+			Detail: completion.Detail,
+			Kind:   completionItemKind(completion.Kind),
+			TextEdit: &messages.TextEdit{
+				Range: messages.Range{
+					Start: messages.Position{Line: position.Line, Character: completion.Start},
+					End:   position,
+				},
+				NewText: completion.Label,
+			},
 		})
 	}
 	return completions
 }
 
+func completionItemKind(kind model.CompletionKind) int {
+	switch kind {
+	case model.CategoryCompletion:
+		return messages.EnumCompletion
+	case model.TimeCompletion:
+		return messages.ValueCompletion
+	case model.TaskCompletion:
+		return messages.ReferenceCompletion
+	default:
+		return messages.TextCompletion
+	}
+}
+
 func (self *Controller) Hover(request *messages.HoverRequest) error {
 
 	date, err := self.service.ParseDateFromName(request.Params.TextDocument.URI)
@@ -186,16 +229,14 @@ func (self *Controller) Definition(request *messages.DefinitionRequest) error {
 	if err != nil {
 		return fmt.Errorf("Error getting date from file: %s for %s", err, request.Params.TextDocument.URI)
 	}
-	output, err := self.service.ShowDailyStatistics(date)
+	uri, err := self.reportURI(model.DaySection, date)
 	if err != nil {
-		return fmt.Errorf("Error getting statistics for file: %s for %s", err, request.Params.TextDocument.URI)
+		return err
 	}
-
-	uri := url.URL{Scheme: "file", Path: string(output)}
 	msg := messages.DefinitionResponse{
 		Response: response(request.Request),
 		Result: &messages.Location{
-			URI: uri.String(),
+			URI: uri,
 			Range: messages.Range{
 				Start: messages.Position{Line: 0, Character: 0},
 				End:   messages.Position{Line: 0, Character: 0},
@@ -206,6 +247,11 @@ func (self *Controller) Definition(request *messages.DefinitionRequest) error {
 
 }
 
+// nextRequestID numbers requests sent from server to client.
+func (self *Controller) nextRequestID() int {
+	return int(self.requestID.Add(1))
+}
+
 func (self *Controller) writeResponse(msg any) error {
 	reply := rpc.EncodeMessage(msg)
 
@@ -0,0 +1,77 @@
+package lspserver
+
+import (
+	"fmt"
+
+	messages "github.com/jborkows/timesheets/internal/lspmessages"
+	"github.com/jborkows/timesheets/internal/model"
+)
+
+func lineRange(lineNumber int, start int, end int) messages.Range {
+	return messages.Range{
+		Start: messages.Position{Line: lineNumber, Character: start},
+		End:   messages.Position{Line: lineNumber, Character: end},
+	}
+}
+
+func categorySymbol(category model.CategoryOutline) messages.DocumentSymbol {
+	children := make([]messages.DocumentSymbol, 0, len(category.Entries))
+	for _, entry := range category.Entries {
+		children = append(children, messages.DocumentSymbol{
+			Name:           entry.Name,
+			Detail:         shortHours(entry.Time) + "h",
+			Kind:           messages.EventSymbol,
+			Range:          lineRange(entry.LineNumber, 0, entry.EndColumn),
+			SelectionRange: lineRange(entry.LineNumber, 0, entry.EndColumn),
+		})
+	}
+	last := category.LastEntry()
+	return messages.DocumentSymbol{
+		Name:   category.Category,
+		Detail: shortHours(category.Time) + "h",
+		Kind:   messages.ClassSymbol,
+		Range: messages.Range{
+			Start: messages.Position{Line: category.FirstLine(), Character: 0},
+			End:   messages.Position{Line: last.LineNumber, Character: last.EndColumn},
+		},
+		SelectionRange: lineRange(category.FirstLine(), 0, len(category.Category)),
+		Children:       children,
+	}
+}
+
+func (self *Controller) DocumentSymbol(request *messages.DocumentSymbolRequest) error {
+	symbols := []messages.DocumentSymbol{}
+	date, err := self.service.ParseDateFromName(request.Params.TextDocument.URI)
+	if err == nil {
+		content := self.content.get(request.Params.TextDocument.URI)
+		for _, category := range self.service.DayOutline(content, date) {
+			symbols = append(symbols, categorySymbol(category))
+		}
+	}
+	msg := messages.DocumentSymbolResponse{
+		Response: response(request.Request),
+		Result:   symbols,
+	}
+	return self.writeResponse(msg)
+}
+
+func (self *Controller) FoldingRange(request *messages.FoldingRangeRequest) error {
+	ranges := []messages.FoldingRange{}
+	date, err := self.service.ParseDateFromName(request.Params.TextDocument.URI)
+	if err == nil {
+		content := self.content.get(request.Params.TextDocument.URI)
+		for _, fold := range self.service.CategoryFolds(content, date) {
+			ranges = append(ranges, messages.FoldingRange{
+				StartLine:     fold.StartLine,
+				EndLine:       fold.EndLine,
+				Kind:          messages.RegionFoldingRange,
+				CollapsedText: fmt.Sprintf("%s (%d entries)", fold.Category, fold.EndLine-fold.StartLine+1),
+			})
+		}
+	}
+	msg := messages.FoldingRangeResponse{
+		Response: response(request.Request),
+		Result:   ranges,
+	}
+	return self.writeResponse(msg)
+}
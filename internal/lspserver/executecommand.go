@@ -0,0 +1,287 @@
+package lspserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	messages "github.com/jborkows/timesheets/internal/lspmessages"
+	"github.com/jborkows/timesheets/internal/model"
+)
+
+// replyFunc answers command with its result or with error response when it failed.
+type replyFunc func(result any, err error) error
+
+// ExecuteCommand answers once command is done, commands changing workspace wait until client applied their edit.
+func (self *Controller) ExecuteCommand(request *messages.ExecuteCommandRequest) error {
+	reply := func(result any, err error) error {
+		if err != nil {
+			log.Printf("Command %s failed: %s", request.Params.Command, err)
+			return self.writeResponse(messages.ErrorResponse{
+				Response: response(request.Request),
+				Error:    &messages.ResponseError{Code: messages.RequestFailed, Message: err.Error()},
+			})
+		}
+		return self.writeResponse(messages.ExecuteCommandResponse{
+			Response: response(request.Request),
+			Result:   result,
+		})
+	}
+	var err error
+	arguments := request.Params.Arguments
+	switch request.Params.Command {
+	case messages.ShowReportCommand:
+		return reply(nil, self.showReport(arguments))
+	case messages.OpenTodayCommand:
+		err = self.openToday(arguments, reply)
+	case messages.CreateDayCommand:
+		err = self.createDay(arguments, reply)
+	case messages.CreateNextDayCommand:
+		err = self.createNextDay(arguments, reply)
+	case messages.MonthReportCommand:
+		return reply(nil, self.monthReport(arguments))
+	case messages.ReindexCommand:
+		return reply(self.reindex(arguments))
+	case messages.ExportMonthCommand:
+		err = self.exportMonth(arguments, reply)
+	default:
+		err = fmt.Errorf("unknown command %s", request.Params.Command)
+	}
+	if err != nil {
+		return reply(nil, err)
+	}
+	return nil
+}
+
+// stringArguments checks there are from min to max text arguments.
+func stringArguments(arguments []any, min int, max int) ([]string, error) {
+	if len(arguments) < min || len(arguments) > max {
+		if min == max {
+			return nil, fmt.Errorf("expected %d arguments, got %d", min, len(arguments))
+		}
+		return nil, fmt.Errorf("expected from %d to %d arguments, got %d", min, max, len(arguments))
+	}
+	result := make([]string, 0, len(arguments))
+	for _, argument := range arguments {
+		text, ok := argument.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected text argument, got %v", argument)
+		}
+		result = append(result, text)
+	}
+	return result, nil
+}
+
+// dateOf reads date from name of the file given by URI.
+func (self *Controller) dateOf(uri string) (time.Time, error) {
+	date, err := self.service.ParseDateFromName(uri)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Error getting date from file: %s for %s", err, uri)
+	}
+	return date, nil
+}
+
+func (self *Controller) showReport(arguments []any) error {
+	values, err := stringArguments(arguments, 2, 2)
+	if err != nil {
+		return err
+	}
+	file, period := values[0], values[1]
+	date, err := self.dateOf(file)
+	if err != nil {
+		return err
+	}
+	kind, err := model.ParseSectionKind(period)
+	if err != nil {
+		return err
+	}
+	uri, err := self.reportURI(kind, date)
+	if err != nil {
+		return err
+	}
+	self.showDocument(uri)
+	return nil
+}
+
+// showDocument asks client to open the document, e.g. a report.
+func (self *Controller) showDocument(uri string) {
+	message := messages.ShowDocumentRequest{
+		Request: messages.Request{
+			RPC:    "2.0",
+			ID:     self.nextRequestID(),
+			Method: "window/showDocument",
+		},
+		Params: messages.ShowDocumentParams{URI: uri, TakeFocus: true},
+	}
+	if err := self.writeResponse(message); err != nil {
+		log.Printf("Error asking to show %s: %s", uri, err)
+	}
+}
+
+func (self *Controller) openToday(arguments []any, reply replyFunc) error {
+	if _, err := stringArguments(arguments, 0, 0); err != nil {
+		return err
+	}
+	self.openDay(time.Now(), reply)
+	return nil
+}
+
+func (self *Controller) createNextDay(arguments []any, reply replyFunc) error {
+	values, err := stringArguments(arguments, 1, 1)
+	if err != nil {
+		return err
+	}
+	date, err := self.dateOf(values[0])
+	if err != nil {
+		return err
+	}
+	self.openDay(date.AddDate(0, 0, 1), reply)
+	return nil
+}
+
+func (self *Controller) createDay(arguments []any, reply replyFunc) error {
+	values, err := stringArguments(arguments, 1, 1)
+	if err != nil {
+		return err
+	}
+	date, err := time.Parse("2006-01-02", values[0])
+	if err != nil {
+		return fmt.Errorf("invalid date %s: %w", values[0], err)
+	}
+	self.openDay(date, reply)
+	return nil
+}
+
+// openDay creates file of the day from template unless it exists and shows it once created.
+func (self *Controller) openDay(date time.Time, reply replyFunc) {
+	uri := self.service.FileForDate(date)
+	if self.service.DayExists(date) {
+		self.showDocument(uri)
+		_ = reply(nil, nil)
+		return
+	}
+	changes := []any{messages.NewCreateFile(uri, messages.CreateFileOptions{IgnoreIfExists: true})}
+	if text := self.service.DayTemplate(date); text != "" {
+		changes = append(changes, insertion(uri, text))
+	}
+	self.applyEdit("Create "+date.Format("2006-01-02"), messages.WorkspaceEdit{DocumentChanges: changes}, func(err error) {
+		if err == nil {
+			self.showDocument(uri)
+		}
+		_ = reply(nil, err)
+	})
+}
+
+// insertion puts text at the beginning of the document.
+func insertion(uri string, text string) messages.TextDocumentEdit {
+	return messages.TextDocumentEdit{
+		TextDocument: messages.OptionalVersionedTextDocumentIdentifier{URI: uri},
+		Edits:        []messages.TextEdit{{NewText: text}},
+	}
+}
+
+func (self *Controller) monthReport(arguments []any) error {
+	values, err := stringArguments(arguments, 0, 1)
+	if err != nil {
+		return err
+	}
+	date := time.Now()
+	if len(values) == 1 {
+		if date, err = self.dateOf(values[0]); err != nil {
+			return err
+		}
+	}
+	uri, err := self.reportURI(model.MonthSection, date)
+	if err != nil {
+		return err
+	}
+	self.showDocument(uri)
+	return nil
+}
+
+func (self *Controller) reindex(arguments []any) (*messages.ReindexResult, error) {
+	if _, err := stringArguments(arguments, 0, 0); err != nil {
+		return nil, err
+	}
+	result, err := self.service.Reindex()
+	if err != nil {
+		return nil, fmt.Errorf("Error reindexing: %w", err)
+	}
+	self.requestSemanticTokensRefresh()
+	self.requestCodeLensRefresh()
+	self.requestReportsRefresh()
+	return &messages.ReindexResult{Files: result.Files, Failed: len(result.Errors)}, nil
+}
+
+// exportMonth replaces export of the month with report in given or configured format,
+// ExportResult is sent once client wrote it.
+func (self *Controller) exportMonth(arguments []any, reply replyFunc) error {
+	values, err := stringArguments(arguments, 1, 2)
+	if err != nil {
+		return err
+	}
+	date, err := self.dateOf(values[0])
+	if err != nil {
+		return err
+	}
+	format := self.service.ReportFormat()
+	if len(values) == 2 {
+		if format, err = model.ParseReportFormat(values[1]); err != nil {
+			return err
+		}
+	}
+	text, err := self.service.MonthExport(date, format)
+	if err != nil {
+		return fmt.Errorf("Error exporting month of %s: %w", values[0], err)
+	}
+	uri := self.service.ExportURI(date, format)
+	self.applyEdit("Export "+date.Format("2006-01"), messages.WorkspaceEdit{
+		DocumentChanges: []any{
+			messages.NewCreateFile(uri, messages.CreateFileOptions{Overwrite: true}),
+			insertion(uri, text),
+		},
+	}, func(err error) {
+		if err != nil {
+			_ = reply(nil, err)
+			return
+		}
+		_ = reply(&messages.ExportResult{URI: uri}, nil)
+	})
+	return nil
+}
+
+// applyEdit asks client to change workspace, e.g. to create a file, done is called with its answer.
+func (self *Controller) applyEdit(label string, edit messages.WorkspaceEdit, done func(error)) {
+	id := self.nextRequestID()
+	message := messages.ApplyWorkspaceEditRequest{
+		Request: messages.Request{
+			RPC:    "2.0",
+			ID:     id,
+			Method: "workspace/applyEdit",
+		},
+		Params: messages.ApplyWorkspaceEditParams{Label: label, Edit: edit},
+	}
+	self.pending.expect(id, func(msg *messages.ResponseMessage) {
+		done(appliedEdit(label, msg))
+	})
+	if err := self.writeResponse(message); err != nil {
+		self.pending.take(id)
+		done(fmt.Errorf("Error asking to apply %s: %w", label, err))
+	}
+}
+
+// appliedEdit tells why edit was not applied, nil when it was.
+func appliedEdit(label string, msg *messages.ResponseMessage) error {
+	if msg.Error != nil {
+		return fmt.Errorf("%s failed: %s", label, msg.Error.Message)
+	}
+	var result messages.ApplyWorkspaceEditResult
+	if err := json.Unmarshal(msg.Result, &result); err != nil {
+		return fmt.Errorf("Error reading answer to %s: %w", label, err)
+	}
+	if !result.Applied {
+		return fmt.Errorf("%s was not applied: %s", label, result.FailureReason)
+	}
+	return nil
+}
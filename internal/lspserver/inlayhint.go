@@ -0,0 +1,48 @@
+package lspserver
+
+import (
+	"fmt"
+	"strconv"
+
+	messages "github.com/jborkows/timesheets/internal/lspmessages"
+	"github.com/jborkows/timesheets/internal/model"
+)
+
+func (self *Controller) InlayHint(request *messages.InlayHintRequest) error {
+	params := request.Params
+	hints := []messages.InlayHint{}
+	date, err := self.service.ParseDateFromName(params.TextDocument.URI)
+	if err == nil {
+		content := self.content.get(params.TextDocument.URI)
+		for _, progress := range self.service.DayProgress(content, date) {
+			if progress.LineNumber < params.Range.Start.Line || progress.LineNumber > params.Range.End.Line {
+				continue
+			}
+			hints = append(hints, progressHint(progress))
+		}
+	}
+	msg := messages.InlayHintResponse{
+		Response: response(request.Request),
+		Result:   hints,
+	}
+	return self.writeResponse(msg)
+}
+
+func progressHint(progress model.LineProgress) messages.InlayHint {
+	label := fmt.Sprintf("%sh Σ %sh", shortHours(progress.Time), shortHours(progress.RunningTotal))
+	if progress.Expected > 0 {
+		label = fmt.Sprintf("%sh Σ %s/%sh", shortHours(progress.Time), shortHours(progress.RunningTotal), shortHours(progress.Expected))
+	}
+	return messages.InlayHint{
+		Position:    messages.Position{Line: progress.LineNumber, Character: progress.EndColumn},
+		Label:       label,
+		Tooltip:     "Time of the entry and total of the day up to this line",
+		PaddingLeft: true,
+	}
+}
+
+// shortHours shows minutes as decimal hours without trailing zeros, e.g. 1.5 or 1.75
+func shortHours(minutes model.Minutes) string {
+	hundredths := (uint32(minutes)*100 + 30) / 60
+	return strconv.FormatFloat(float64(hundredths)/100, 'f', -1, 64)
+}
@@ -0,0 +1,62 @@
+package lspserver
+
+import (
+	"fmt"
+
+	messages "github.com/jborkows/timesheets/internal/lspmessages"
+	"github.com/jborkows/timesheets/internal/model"
+)
+
+func occurrenceRange(occurrence model.Occurrence) messages.Range {
+	return messages.Range{
+		Start: messages.Position{Line: occurrence.LineNumber, Character: occurrence.Start},
+		End:   messages.Position{Line: occurrence.LineNumber, Character: occurrence.End},
+	}
+}
+
+// taskAt finds task under cursor in document, with date of the document.
+func (self *Controller) taskAt(params messages.TextDocumentPositionParams) (string, bool) {
+	date, err := self.service.ParseDateFromName(params.TextDocument.URI)
+	if err != nil {
+		return "", false
+	}
+	content := self.content.get(params.TextDocument.URI)
+	if params.Position.Line >= len(content) {
+		return "", false
+	}
+	return self.service.TaskAt(content[params.Position.Line], params.Position.Character, date)
+}
+
+func (self *Controller) References(request *messages.ReferencesRequest) error {
+	locations := []messages.Location{}
+	if task, ok := self.taskAt(request.Params.TextDocumentPositionParams); ok {
+		references, err := self.service.TaskReferences(task, self.content.get)
+		if err != nil {
+			return fmt.Errorf("Error finding references of %s: %w", task, err)
+		}
+		for _, reference := range references {
+			locations = append(locations, messages.Location{URI: reference.URI, Range: occurrenceRange(reference.Occurrence)})
+		}
+	}
+	msg := messages.ReferencesResponse{
+		Response: response(request.Request),
+		Result:   locations,
+	}
+	return self.writeResponse(msg)
+}
+
+func (self *Controller) DocumentHighlight(request *messages.DocumentHighlightRequest) error {
+	highlights := []messages.DocumentHighlight{}
+	if task, ok := self.taskAt(request.Params); ok {
+		uri := request.Params.TextDocument.URI
+		date, _ := self.service.ParseDateFromName(uri)
+		for _, occurrence := range self.service.TaskOccurrences(self.content.get(uri), task, date) {
+			highlights = append(highlights, messages.DocumentHighlight{Range: occurrenceRange(occurrence), Kind: messages.TextHighlight})
+		}
+	}
+	msg := messages.DocumentHighlightResponse{
+		Response: response(request.Request),
+		Result:   highlights,
+	}
+	return self.writeResponse(msg)
+}
@@ -0,0 +1,106 @@
+package lspserver
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	messages "github.com/jborkows/timesheets/internal/lspmessages"
+	"github.com/jborkows/timesheets/internal/model"
+)
+
+func (self *Controller) renameTarget(params messages.TextDocumentPositionParams) (*model.RenameTarget, bool) {
+	date, err := self.service.ParseDateFromName(params.TextDocument.URI)
+	if err != nil {
+		return nil, false
+	}
+	content := self.content.get(params.TextDocument.URI)
+	if params.Position.Line >= len(content) {
+		return nil, false
+	}
+	return self.service.RenameTargetAt(content[params.Position.Line], params.Position.Line, params.Position.Character, date)
+}
+
+func (self *Controller) PrepareRename(request *messages.PrepareRenameRequest) error {
+	var result *messages.PrepareRenameResult
+	if target, ok := self.renameTarget(request.Params); ok {
+		result = &messages.PrepareRenameResult{Range: occurrenceRange(target.Occurrence), Placeholder: target.Name}
+	}
+	msg := messages.PrepareRenameResponse{
+		Response: response(request.Request),
+		Result:   result,
+	}
+	return self.writeResponse(msg)
+}
+
+// Rename asks client to apply the edit and answers once it did, stored data and configuration are renamed
+// only when the edit was applied. Answer holds no further changes, as they are already made.
+func (self *Controller) Rename(request *messages.RenameRequest) error {
+	reply := func(err error) error {
+		msg := messages.RenameResponse{Response: response(request.Request)}
+		if err != nil {
+			log.Printf("Rename failed: %s", err)
+			msg.Error = &messages.ResponseError{Code: messages.RequestFailed, Message: err.Error()}
+		} else {
+			msg.Result = &messages.WorkspaceEdit{Changes: map[string][]messages.TextEdit{}}
+		}
+		return self.writeResponse(msg)
+	}
+	target, edit, err := self.rename(request.Params)
+	if err != nil {
+		return reply(err)
+	}
+	newName := request.Params.NewName
+	self.applyEdit(fmt.Sprintf("Rename %s %s", target.Kind, target.Name), *edit, func(err error) {
+		if err == nil {
+			err = self.service.ApplyRename(target.Kind, target.Name, newName)
+		}
+		if err == nil {
+			self.requestSemanticTokensRefresh()
+			self.requestCodeLensRefresh()
+			self.requestReportsRefresh()
+		}
+		_ = reply(err)
+	})
+	return nil
+}
+
+func (self *Controller) rename(params messages.RenameParams) (*model.RenameTarget, *messages.WorkspaceEdit, error) {
+	target, ok := self.renameTarget(params.TextDocumentPositionParams)
+	if !ok {
+		return nil, nil, fmt.Errorf("only category or task can be renamed")
+	}
+	fileEdits, err := self.service.Rename(target.Kind, target.Name, params.NewName, self.content.get)
+	if err != nil {
+		return nil, nil, err
+	}
+	if target.Kind == model.CategoryRename {
+		if configEdit, ok := self.configEdit(target.Name); ok {
+			fileEdits = append(fileEdits, configEdit)
+		}
+	}
+	edit := &messages.WorkspaceEdit{Changes: map[string][]messages.TextEdit{}}
+	for _, fileEdit := range fileEdits {
+		for _, occurrence := range fileEdit.Occurrences {
+			edit.Changes[fileEdit.URI] = append(edit.Changes[fileEdit.URI], messages.TextEdit{
+				Range:   occurrenceRange(occurrence),
+				NewText: params.NewName,
+			})
+		}
+	}
+	return target, edit, nil
+}
+
+// configEdit finds category in config file, so it is renamed there as well.
+func (self *Controller) configEdit(category string) (model.FileEdit, bool) {
+	path, uri, ok := self.configFile()
+	if !ok {
+		return model.FileEdit{}, false
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		log.Printf("Error reading config %s: %s", path, err)
+		return model.FileEdit{}, false
+	}
+	return model.FileEdit{URI: uri, Occurrences: model.CategoryOccurrences(string(content), category)}, true
+}
@@ -0,0 +1,85 @@
+package lspserver
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	messages "github.com/jborkows/timesheets/internal/lspmessages"
+	"github.com/jborkows/timesheets/internal/model"
+)
+
+// shownReports remembers reports served to client, to refresh them when data changes.
+type shownReports struct {
+	mutex sync.Mutex
+	uris  map[string]struct{}
+}
+
+func newShownReports() *shownReports {
+	return &shownReports{uris: map[string]struct{}{}}
+}
+
+func (r *shownReports) add(uri string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.uris[uri] = struct{}{}
+}
+
+func (r *shownReports) remove(uri string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	delete(r.uris, uri)
+}
+
+func (r *shownReports) all() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	result := make([]string, 0, len(r.uris))
+	for uri := range r.uris {
+		result = append(result, uri)
+	}
+	return result
+}
+
+// reportURI names report of the period, served as virtual document when client supports it, written to a file otherwise.
+func (self *Controller) reportURI(kind model.SectionKind, date time.Time) (string, error) {
+	if self.virtualReports {
+		return self.service.ReportURI(kind, date), nil
+	}
+	uri, err := self.service.ReportFile(kind, date)
+	if err != nil {
+		return "", fmt.Errorf("Error writing %s report: %w", kind, err)
+	}
+	return uri, nil
+}
+
+func (self *Controller) TextDocumentContent(request *messages.TextDocumentContentRequest) error {
+	text, err := self.service.ReportContent(request.Params.URI)
+	if err != nil {
+		return fmt.Errorf("Error rendering report %s: %w", request.Params.URI, err)
+	}
+	self.reports.add(request.Params.URI)
+	msg := messages.TextDocumentContentResponse{
+		Response: response(request.Request),
+		Result:   messages.TextDocumentContentResult{Text: text},
+	}
+	return self.writeResponse(msg)
+}
+
+// requestReportsRefresh asks client to re-request reports it shows, as they change when any file is saved.
+func (self *Controller) requestReportsRefresh() {
+	for _, uri := range self.reports.all() {
+		message := messages.TextDocumentContentRefreshRequest{
+			Request: messages.Request{
+				RPC:    "2.0",
+				ID:     self.nextRequestID(),
+				Method: "workspace/textDocumentContent/refresh",
+			},
+			Params: messages.TextDocumentContentParams{URI: uri},
+		}
+		if err := self.writeResponse(message); err != nil {
+			log.Printf("Error requesting refresh of %s: %s", uri, err)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package lspserver
+
+import (
+	"log"
+	"sync"
+
+	messages "github.com/jborkows/timesheets/internal/lspmessages"
+)
+
+// pendingRequests remembers requests sent to client which wait for its answer, e.g. workspace/applyEdit.
+type pendingRequests struct {
+	mutex     sync.Mutex
+	callbacks map[int]func(*messages.ResponseMessage)
+}
+
+func newPendingRequests() *pendingRequests {
+	return &pendingRequests{callbacks: map[int]func(*messages.ResponseMessage){}}
+}
+
+func (r *pendingRequests) expect(id int, callback func(*messages.ResponseMessage)) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.callbacks[id] = callback
+}
+
+func (r *pendingRequests) take(id int) (func(*messages.ResponseMessage), bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	callback, ok := r.callbacks[id]
+	delete(r.callbacks, id)
+	return callback, ok
+}
+
+// onResponse passes answer of client to the one waiting for it, answers nobody waits for are dropped.
+func (self *Controller) onResponse(msg *messages.ResponseMessage) {
+	if msg.ID == nil {
+		return
+	}
+	callback, ok := self.pending.take(*msg.ID)
+	if !ok {
+		if msg.Error != nil {
+			log.Printf("Request %d failed: %s", *msg.ID, msg.Error.Message)
+		}
+		return
+	}
+	callback(msg)
+}
@@ -30,6 +30,13 @@ func (self *Controller) route(method string, contents []byte) (any, error) {
 		log.Printf("Received msg with contents: %s", contents)
 	}
 	switch method {
+	case "":
+		var reply messages.ResponseMessage
+		if err := json.Unmarshal(contents, &reply); err != nil {
+			return nil, err
+		}
+		self.onResponse(&reply)
+		return nil, nil
 	case "initialize":
 		var request messages.InitializeRequest
 		if err := json.Unmarshal(contents, &request); err != nil {
@@ -39,10 +46,26 @@ func (self *Controller) route(method string, contents []byte) (any, error) {
 		log.Printf("Connected to: %s %s",
 			request.Params.ClientInfo.Name,
 			request.Params.ClientInfo.Version)
+		self.virtualReports = request.Params.SupportsTextDocumentContent()
+		self.watchedFiles = request.Params.SupportsWatchedFilesRegistration()
 
 		msg := messages.NewInitializeResponse(response(request.Request))
 		return msg, nil
 
+	case "initialized":
+		if self.watchedFiles {
+			self.registerFileWatchers()
+		} else {
+			log.Println("Client cannot register file watchers, files changed outside of editor need reindex")
+		}
+		return nil, nil
+	case "workspace/didChangeWatchedFiles":
+		var request messages.DidChangeWatchedFilesNotification
+		if err := json.Unmarshal(contents, &request); err != nil {
+			return nil, err
+		}
+		self.onWatchedFilesChange(&request)
+		return nil, nil
 	case "textDocument/didOpen":
 		var request messages.DidOpenTextDocumentNotification
 		if err := json.Unmarshal(contents, &request); err != nil {
@@ -50,6 +73,13 @@ func (self *Controller) route(method string, contents []byte) (any, error) {
 		}
 		self.onOpen(&request)
 		return nil, nil
+	case "textDocument/didClose":
+		var request messages.DidCloseTextDocumentNotification
+		if err := json.Unmarshal(contents, &request); err != nil {
+			return nil, err
+		}
+		self.onClose(&request)
+		return nil, nil
 	case "textDocument/didChange":
 		var request messages.TextDocumentDidChangeNotification
 		if err := json.Unmarshal(contents, &request); err != nil {
@@ -106,6 +136,126 @@ func (self *Controller) route(method string, contents []byte) (any, error) {
 			return nil, fmt.Errorf("Error formatting: %w", err)
 		}
 		return nil, nil
+	case "textDocument/codeAction":
+		var request messages.CodeActionRequest
+		if err := json.Unmarshal(contents, &request); err != nil {
+			return nil, err
+		}
+		err := self.CodeAction(&request)
+		if err != nil {
+			return nil, fmt.Errorf("Error getting code actions: %w", err)
+		}
+		return nil, nil
+	case "textDocument/inlayHint":
+		var request messages.InlayHintRequest
+		if err := json.Unmarshal(contents, &request); err != nil {
+			return nil, err
+		}
+		err := self.InlayHint(&request)
+		if err != nil {
+			return nil, fmt.Errorf("Error getting inlay hints: %w", err)
+		}
+		return nil, nil
+	case "textDocument/codeLens":
+		var request messages.CodeLensRequest
+		if err := json.Unmarshal(contents, &request); err != nil {
+			return nil, err
+		}
+		err := self.CodeLens(&request)
+		if err != nil {
+			return nil, fmt.Errorf("Error getting code lenses: %w", err)
+		}
+		return nil, nil
+	case "workspace/executeCommand":
+		var request messages.ExecuteCommandRequest
+		if err := json.Unmarshal(contents, &request); err != nil {
+			return nil, err
+		}
+		err := self.ExecuteCommand(&request)
+		if err != nil {
+			return nil, fmt.Errorf("Error executing command: %w", err)
+		}
+		return nil, nil
+	case "textDocument/references":
+		var request messages.ReferencesRequest
+		if err := json.Unmarshal(contents, &request); err != nil {
+			return nil, err
+		}
+		err := self.References(&request)
+		if err != nil {
+			return nil, fmt.Errorf("Error getting references: %w", err)
+		}
+		return nil, nil
+	case "textDocument/documentHighlight":
+		var request messages.DocumentHighlightRequest
+		if err := json.Unmarshal(contents, &request); err != nil {
+			return nil, err
+		}
+		err := self.DocumentHighlight(&request)
+		if err != nil {
+			return nil, fmt.Errorf("Error getting document highlights: %w", err)
+		}
+		return nil, nil
+	case "textDocument/prepareRename":
+		var request messages.PrepareRenameRequest
+		if err := json.Unmarshal(contents, &request); err != nil {
+			return nil, err
+		}
+		err := self.PrepareRename(&request)
+		if err != nil {
+			return nil, fmt.Errorf("Error preparing rename: %w", err)
+		}
+		return nil, nil
+	case "textDocument/rename":
+		var request messages.RenameRequest
+		if err := json.Unmarshal(contents, &request); err != nil {
+			return nil, err
+		}
+		err := self.Rename(&request)
+		if err != nil {
+			return nil, fmt.Errorf("Error renaming: %w", err)
+		}
+		return nil, nil
+	case "textDocument/documentSymbol":
+		var request messages.DocumentSymbolRequest
+		if err := json.Unmarshal(contents, &request); err != nil {
+			return nil, err
+		}
+		err := self.DocumentSymbol(&request)
+		if err != nil {
+			return nil, fmt.Errorf("Error getting document symbols: %w", err)
+		}
+		return nil, nil
+	case "textDocument/foldingRange":
+		var request messages.FoldingRangeRequest
+		if err := json.Unmarshal(contents, &request); err != nil {
+			return nil, err
+		}
+		err := self.FoldingRange(&request)
+		if err != nil {
+			return nil, fmt.Errorf("Error getting folding ranges: %w", err)
+		}
+		return nil, nil
+	case "workspace/textDocumentContent":
+		var request messages.TextDocumentContentRequest
+		if err := json.Unmarshal(contents, &request); err != nil {
+			return nil, err
+		}
+		err := self.TextDocumentContent(&request)
+		if err != nil {
+			return nil, fmt.Errorf("Error getting document content: %w", err)
+		}
+		return nil, nil
+	case "workspace/symbol":
+		var request messages.WorkspaceSymbolRequest
+		if err := json.Unmarshal(contents, &request); err != nil {
+			return nil, err
+		}
+		err := self.WorkspaceSymbol(&request)
+		if err != nil {
+			return nil, fmt.Errorf("Error getting workspace symbols: %w", err)
+		}
+		return nil, nil
 	case "textDocument/semanticTokens/full":
 		var request messages.SemanticTokensRequest
 		if err := json.Unmarshal(contents, &request); err != nil {
@@ -0,0 +1,89 @@
+package lspserver
+
+import (
+	"log"
+	"path/filepath"
+
+	messages "github.com/jborkows/timesheets/internal/lspmessages"
+	"github.com/jborkows/timesheets/internal/model"
+)
+
+const watchedFilesRegistration = "timesheets-watched-files"
+
+// registerFileWatchers asks client to notify about timesheet files changed outside of editor, e.g. by git pull,
+// and about changes of config file.
+func (self *Controller) registerFileWatchers() {
+	watchers := []messages.FileSystemWatcher{{GlobPattern: "**/*.tsf"}}
+	if path, _, ok := self.configFile(); ok {
+		watchers = append(watchers, messages.FileSystemWatcher{GlobPattern: messages.RelativePattern{
+			BaseURI: model.FileURI(filepath.Dir(path)),
+			Pattern: filepath.Base(path),
+		}})
+	}
+	message := messages.RegisterCapabilityRequest{
+		Request: messages.Request{
+			RPC:    "2.0",
+			ID:     self.nextRequestID(),
+			Method: "client/registerCapability",
+		},
+		Params: messages.RegistrationParams{
+			Registrations: []messages.Registration{
+				{
+					ID:     watchedFilesRegistration,
+					Method: "workspace/didChangeWatchedFiles",
+					RegisterOptions: messages.DidChangeWatchedFilesRegistrationOptions{
+						Watchers: watchers,
+					},
+				},
+			},
+		},
+	}
+	log.Println("Registering file watchers")
+	if err := self.writeResponse(message); err != nil {
+		log.Printf("Error registering file watchers: %s", err)
+	}
+}
+
+// onWatchedFilesChange stores files changed on disk, open documents are skipped as didChange and didSave own them.
+func (self *Controller) onWatchedFilesChange(msg *messages.DidChangeWatchedFilesNotification) {
+	changed := false
+	for _, change := range msg.Params.Changes {
+		if path, uri, ok := self.configFile(); ok && change.URI == uri {
+			changed = self.reloadConfig(path) || changed
+			continue
+		}
+		if self.content.isOpen(change.URI) {
+			continue
+		}
+		changed = true
+		if change.Type == messages.FileDeleted {
+			self.forgetFile(change.URI)
+			continue
+		}
+		_, errors, err := self.service.ProcessFromDisk(change.URI)
+		if err != nil {
+			log.Printf("Error processing changed file %s: %s", change.URI, err)
+			continue
+		}
+		self.notifyAboutErrors(errors, change.URI)
+	}
+	if !changed {
+		return
+	}
+	self.requestSemanticTokensRefresh()
+	self.requestCodeLensRefresh()
+	self.requestReportsRefresh()
+}
+
+func (self *Controller) forgetFile(uri string) {
+	date, err := self.service.ParseDateFromName(uri)
+	if err != nil {
+		log.Printf("Error getting date from file: %s for %s", err, uri)
+		return
+	}
+	if err := self.service.RemoveDay(date); err != nil {
+		log.Printf("Error removing data of deleted file %s: %s", uri, err)
+		return
+	}
+	self.notifyAboutErrors(nil, uri)
+}
@@ -0,0 +1,40 @@
+package lspserver
+
+import (
+	"fmt"
+
+	messages "github.com/jborkows/timesheets/internal/lspmessages"
+	"github.com/jborkows/timesheets/internal/model"
+)
+
+func symbolKind(kind model.SymbolKind) int {
+	switch kind {
+	case model.TaskSymbol:
+		return messages.PropertySymbol
+	case model.CategorySymbol:
+		return messages.ClassSymbol
+	default:
+		return messages.FileSymbol
+	}
+}
+
+func (self *Controller) WorkspaceSymbol(request *messages.WorkspaceSymbolRequest) error {
+	symbols, err := self.service.Symbols(request.Params.Query, self.content.get)
+	if err != nil {
+		return fmt.Errorf("Error finding symbols for %s: %w", request.Params.Query, err)
+	}
+	result := make([]messages.SymbolInformation, 0, len(symbols))
+	for _, symbol := range symbols {
+		result = append(result, messages.SymbolInformation{
+			Name:          symbol.Name,
+			Kind:          symbolKind(symbol.Kind),
+			Location:      messages.Location{URI: symbol.URI, Range: occurrenceRange(symbol.Occurrence)},
+			ContainerName: symbol.Detail,
+		})
+	}
+	msg := messages.WorkspaceSymbolResponse{
+		Response: response(request.Request),
+		Result:   result,
+	}
+	return self.writeResponse(msg)
+}
@@ -0,0 +1,202 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const balanceMonthLayout = "2006-01"
+
+// balanceDefinition enables running balance of worked and required time from Since (YYYY-MM) on.
+// At the end of a month at most CarryOver hours of surplus are kept (unlimited when not set),
+// surplus not used within ExpiresAfter months is lost (never when 0).
+type balanceDefinition struct {
+	Since        string
+	CarryOver    *float64
+	ExpiresAfter int
+}
+
+func (definition *balanceDefinition) validate() error {
+	if definition.Since != "" {
+		if _, err := time.Parse(balanceMonthLayout, definition.Since); err != nil {
+			return fmt.Errorf("balance: invalid month %q, expected YYYY-MM", definition.Since)
+		}
+	}
+	if definition.CarryOver != nil && *definition.CarryOver < 0 {
+		return fmt.Errorf("balance: invalid carry over %v", *definition.CarryOver)
+	}
+	if definition.ExpiresAfter < 0 {
+		return fmt.Errorf("balance: invalid expiry %d", definition.ExpiresAfter)
+	}
+	return nil
+}
+
+// LedgerMonth is saved time worked in the month against time required in it, leave lowers requirement.
+// Only worked time is stored, required one follows current configuration.
+type LedgerMonth struct {
+	Month    Day
+	Worked   Minutes
+	Required Minutes
+}
+
+// BalanceMonth is state of the balance at the end of the month, Expired is surplus lost then.
+type BalanceMonth struct {
+	LedgerMonth
+	Expired Minutes
+	Balance SignedMinutes
+}
+
+// surplus is time worked above requirement in the month, used up oldest first.
+type surplus struct {
+	month Day
+	time  Minutes
+}
+
+type balanceRules struct {
+	carryOver    *Minutes
+	expiresAfter int
+}
+
+func (config *Config) balanceRules() balanceRules {
+	rules := balanceRules{expiresAfter: config.Balance.ExpiresAfter}
+	if config.Balance.CarryOver != nil {
+		carryOver := Minutes(*config.Balance.CarryOver * 60)
+		rules.carryOver = &carryOver
+	}
+	return rules
+}
+
+// BalanceEnabled tells whether balance month was configured.
+func (config *Config) BalanceEnabled() bool {
+	return config.Balance.Since != ""
+}
+
+func monthsBetween(from Day, to Day) int {
+	begin, end := time.Time(from), time.Time(to)
+	return (end.Year()-begin.Year())*12 + int(end.Month()) - int(begin.Month())
+}
+
+// expire drops surplus older than allowed and then the oldest one above carry over limit.
+func (rules balanceRules) expire(surpluses []surplus, month Day) ([]surplus, Minutes) {
+	var expired Minutes
+	kept := surpluses[:0]
+	for _, entry := range surpluses {
+		if rules.expiresAfter > 0 && monthsBetween(entry.month, month) >= rules.expiresAfter {
+			expired += entry.time
+			continue
+		}
+		kept = append(kept, entry)
+	}
+	if rules.carryOver == nil {
+		return kept, expired
+	}
+	var total Minutes
+	for _, entry := range kept {
+		total += entry.time
+	}
+	for i := 0; i < len(kept) && total > *rules.carryOver; i++ {
+		cut := min(kept[i].time, total-*rules.carryOver)
+		kept[i].time -= cut
+		total -= cut
+		expired += cut
+	}
+	return kept, expired
+}
+
+// running goes through months in order, the last one is not closed so nothing expires in it.
+func (rules balanceRules) running(ledger []LedgerMonth) []BalanceMonth {
+	result := make([]BalanceMonth, 0, len(ledger))
+	var surpluses []surplus
+	var debt Minutes
+	for i, month := range ledger {
+		if month.Worked >= month.Required {
+			gain := month.Worked - month.Required
+			repaid := min(debt, gain)
+			debt -= repaid
+			if gain > repaid {
+				surpluses = append(surpluses, surplus{month: month.Month, time: gain - repaid})
+			}
+		} else {
+			missing := month.Required - month.Worked
+			for len(surpluses) > 0 && missing > 0 {
+				used := min(surpluses[0].time, missing)
+				surpluses[0].time -= used
+				missing -= used
+				if surpluses[0].time == 0 {
+					surpluses = surpluses[1:]
+				}
+			}
+			debt += missing
+		}
+		current := BalanceMonth{LedgerMonth: month}
+		if i < len(ledger)-1 {
+			surpluses, current.Expired = rules.expire(surpluses, month.Month)
+		}
+		current.Balance = -SignedMinutes(debt)
+		for _, entry := range surpluses {
+			current.Balance += SignedMinutes(entry.time)
+		}
+		result = append(result, current)
+	}
+	return result
+}
+
+// updateLedger stores saved time worked in the month of the date.
+func (self *Service) updateLedger(ctx context.Context, repository Saver, queryer Queryer, date time.Time) error {
+	timesheet := TimesheetForDate(date)
+	statistics, err := queryer.Monthly(ctx, timesheet)
+	if err != nil {
+		return fmt.Errorf("failed to get monthly statistics: %w", err)
+	}
+	ledger := LedgerMonth{Month: timesheet.Month().BeginDate}
+	for _, statistic := range statistics {
+		// holidays are stored as rows without category
+		if statistic.Category == "" {
+			continue
+		}
+		ledger.Worked += minutesOf(statistic.Monthly.Hours, statistic.Monthly.Minutes)
+	}
+	return repository.SaveBalance(ctx, ledger)
+}
+
+// Balance lists months from the configured one up to the month of the date with running balance after each of them.
+// Required time is counted from current calendar and saved leave, the month of the date only up to the date.
+func (self *Service) Balance(date time.Time) ([]BalanceMonth, error) {
+	if !self.config().BalanceEnabled() {
+		return nil, nil
+	}
+	since, err := time.Parse(balanceMonthLayout, self.config().Balance.Since)
+	if err != nil {
+		return nil, err
+	}
+	ledger, err := statistics(self, date, func(ctx context.Context, queryer Queryer, date time.Time) ([]LedgerMonth, error) {
+		saved, err := queryer.Ledger(ctx, TimesheetForDate(date))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ledger: %w", err)
+		}
+		worked := make(map[string]Minutes, len(saved))
+		for _, entry := range saved {
+			worked[time.Time(entry.Month).Format(balanceMonthLayout)] = entry.Worked
+		}
+		lastMonth := date.Format(balanceMonthLayout)
+		var ledger []LedgerMonth
+		for first := since; first.Format(balanceMonthLayout) <= lastMonth; first = first.AddDate(0, 1, 0) {
+			month := TimesheetForDate(first).Month()
+			last := time.Time(month.EndDate)
+			if first.Format(balanceMonthLayout) == lastMonth {
+				last = date
+			}
+			required, err := self.requiredTime(ctx, queryer, first, last)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get required time: %w", err)
+			}
+			ledger = append(ledger, LedgerMonth{Month: month.BeginDate, Worked: worked[first.Format(balanceMonthLayout)], Required: required})
+		}
+		return ledger, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return self.config().balanceRules().running(ledger), nil
+}
@@ -0,0 +1,79 @@
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+func observed(rule holidayRule, how string) holidayRule {
+	rule.Observed = how
+	return rule
+}
+
+func since(rule holidayRule, year int) holidayRule {
+	rule.Since = year
+	return rule
+}
+
+// calendars are public holidays of countries selectable in config by their code, regional ones are not included.
+var calendars = map[string][]holidayRule{
+	"PL": {
+		fixedRule("Nowy Rok", 1, 1),
+		fixedRule("Trzech Króli", 1, 6),
+		easterRule("Wielkanoc", 0),
+		easterRule("Poniedziałek Wielkanocny", 1),
+		fixedRule("Święto Pracy", 5, 1),
+		fixedRule("Święto Konstytucji 3 Maja", 5, 3),
+		easterRule("Zielone Świątki", 49),
+		easterRule("Boże Ciało", 60),
+		fixedRule("Wniebowzięcie Najświętszej Maryi Panny", 8, 15),
+		fixedRule("Wszystkich Świętych", 11, 1),
+		fixedRule("Narodowe Święto Niepodległości", 11, 11),
+		since(fixedRule("Wigilia", 12, 24), 2025),
+		fixedRule("Boże Narodzenie", 12, 25),
+		fixedRule("Drugi dzień Bożego Narodzenia", 12, 26),
+	},
+	"US": {
+		observed(fixedRule("New Year's Day", 1, 1), ObservedNearest),
+		nthWeekdayRule("Martin Luther King Jr. Day", 1, time.Monday, 3),
+		nthWeekdayRule("Washington's Birthday", 2, time.Monday, 3),
+		nthWeekdayRule("Memorial Day", 5, time.Monday, -1),
+		since(observed(fixedRule("Juneteenth", 6, 19), ObservedNearest), 2021),
+		observed(fixedRule("Independence Day", 7, 4), ObservedNearest),
+		nthWeekdayRule("Labor Day", 9, time.Monday, 1),
+		nthWeekdayRule("Columbus Day", 10, time.Monday, 2),
+		observed(fixedRule("Veterans Day", 11, 11), ObservedNearest),
+		nthWeekdayRule("Thanksgiving Day", 11, time.Thursday, 4),
+		observed(fixedRule("Christmas Day", 12, 25), ObservedNearest),
+	},
+	"DE": {
+		fixedRule("Neujahr", 1, 1),
+		easterRule("Karfreitag", -2),
+		easterRule("Ostermontag", 1),
+		fixedRule("Tag der Arbeit", 5, 1),
+		easterRule("Christi Himmelfahrt", 39),
+		easterRule("Pfingstmontag", 50),
+		fixedRule("Tag der Deutschen Einheit", 10, 3),
+		fixedRule("Erster Weihnachtstag", 12, 25),
+		fixedRule("Zweiter Weihnachtstag", 12, 26),
+	},
+	"GB": {
+		observed(fixedRule("New Year's Day", 1, 1), ObservedNext),
+		easterRule("Good Friday", -2),
+		easterRule("Easter Monday", 1),
+		nthWeekdayRule("Early May bank holiday", 5, time.Monday, 1),
+		nthWeekdayRule("Spring bank holiday", 5, time.Monday, -1),
+		nthWeekdayRule("Summer bank holiday", 8, time.Monday, -1),
+		observed(fixedRule("Christmas Day", 12, 25), ObservedNext),
+		observed(fixedRule("Boxing Day", 12, 26), ObservedNext),
+	},
+}
+
+func Countries() []string {
+	countries := make([]string, 0, len(calendars))
+	for country := range calendars {
+		countries = append(countries, country)
+	}
+	sort.Strings(countries)
+	return countries
+}
@@ -0,0 +1,189 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"unicode"
+)
+
+// FieldAtCursor tells which field of the line is being typed and what is known about the fields before it.
+type FieldAtCursor struct {
+	State    AnalyzerState
+	Start    int
+	Prefix   string
+	Category string
+	Task     string
+}
+
+// FieldAt reads line up to column and finds the field the column is in.
+// Prefix is text typed so far in that field; for comments it spans all their words.
+func (parser *Parser) FieldAt(line string, column int) FieldAtCursor {
+	runes := []rune(line)
+	column = max(0, min(column, len(runes)))
+	typed := string(runes[:column])
+	trimmed := strings.TrimLeftFunc(typed, unicode.IsSpace)
+	offset := len([]rune(typed)) - len([]rune(trimmed))
+	tokens := tokenize(trimmed, offset)
+
+	// last word is not finished yet, so it does not move analyzer to next field
+	complete := len(tokens)
+	for complete > 0 {
+		if _, ok := tokens[complete-1].(*space); ok {
+			break
+		}
+		complete--
+	}
+
+	analyzer := &tokenAnalyzer{
+		Parser: parser,
+		tokens: make([]token, 0),
+		state:  StateCategory,
+		entry:  &TimesheetEntry{},
+	}
+	fieldStart := offset
+	for _, t := range tokens[:complete] {
+		before := analyzer.state
+		if err := analyzer.analyze(t); err != nil {
+			break
+		}
+		if analyzer.state == before {
+			continue
+		}
+		fieldStart = t.bounds().end
+		if _, ok := t.(*word); ok && analyzer.state == StateComment && analyzer.entry.Task == nil {
+			fieldStart = t.bounds().start
+		}
+	}
+
+	fieldStart = min(fieldStart, column)
+	prefix := strings.TrimLeft(string(runes[fieldStart:column]), " ")
+	field := FieldAtCursor{
+		State:    analyzer.state,
+		Start:    column - len([]rune(prefix)),
+		Prefix:   prefix,
+		Category: analyzer.entry.Category,
+	}
+	if analyzer.entry.Task != nil {
+		field.Task = *analyzer.entry.Task
+	}
+	return field
+}
+
+type CompletionKind int
+
+const (
+	CategoryCompletion CompletionKind = iota
+	TimeCompletion
+	TaskCompletion
+	CommentCompletion
+)
+
+// Completion replaces text of the line from column Start up to cursor with Label.
+type Completion struct {
+	Label  string
+	Detail string
+	Kind   CompletionKind
+	Start  int
+}
+
+const historyCompletionsLimit = 20
+
+var commonDurations = []string{"0.25", "0.5", "1.0", "1.5", "2.0", "4.0", "8.0", "30m", "1h", "1h30m"}
+
+// Completions proposes values for the field of the line at column, using history of saved entries for tasks and comments.
+func (self *Service) Completions(line string, column int) []Completion {
+	if isLeaveLine(line) {
+		return self.leaveCompletions(line, column)
+	}
+	field := self.parser().FieldAt(line, column)
+	completions := []Completion{}
+	switch field.State {
+	case StateCategory:
+		for _, category := range self.PossibleCategories() {
+			completions = append(completions, Completion{Label: category, Detail: "Category", Kind: CategoryCompletion, Start: field.Start})
+		}
+	case StateHours:
+		for _, duration := range commonDurations {
+			completions = append(completions, Completion{Label: duration, Detail: "Time", Kind: TimeCompletion, Start: field.Start})
+		}
+	case StateTask:
+		tasks, err := self.recentTasks()
+		if err != nil {
+			log.Printf("Error getting recent tasks: %s", err)
+		}
+		for _, task := range tasks {
+			if !strings.HasPrefix(task, field.Prefix) {
+				continue
+			}
+			completions = append(completions, Completion{Label: task, Detail: "Recent task", Kind: TaskCompletion, Start: field.Start})
+		}
+		completions = append(completions, self.commentCompletions(field)...)
+	case StateComment:
+		completions = append(completions, self.commentCompletions(field)...)
+	}
+	return completions
+}
+
+// leaveCompletions proposes kinds while the first word is typed and durations for the second one.
+func (self *Service) leaveCompletions(line string, column int) []Completion {
+	runes := []rune(line)
+	column = max(0, min(column, len(runes)))
+	typed := strings.TrimLeftFunc(string(runes[:column]), unicode.IsSpace)
+	completions := []Completion{}
+	kind, rest, found := strings.Cut(typed, " ")
+	switch {
+	case !found:
+		for _, kind := range self.config().LeaveKinds() {
+			completions = append(completions, Completion{Label: leavePrefix + kind, Detail: "Leave", Kind: CategoryCompletion, Start: column - len([]rune(typed))})
+		}
+	case self.config().IsLeaveKind(strings.TrimPrefix(kind, leavePrefix)) && !strings.Contains(strings.TrimLeft(rest, " "), " "):
+		start := column - len([]rune(strings.TrimLeft(rest, " ")))
+		for _, duration := range append([]string{halfDay}, commonDurations...) {
+			completions = append(completions, Completion{Label: duration, Detail: "Time", Kind: TimeCompletion, Start: start})
+		}
+	}
+	return completions
+}
+
+func (self *Service) commentCompletions(field FieldAtCursor) []Completion {
+	var comments []string
+	err := self.repository.Transactional(context.TODO(), func(ctx context.Context, repository Saver, queryer Queryer) error {
+		found, err := queryer.FrequentComments(ctx, field.Category, field.Task, historyCompletionsLimit)
+		if err != nil {
+			return fmt.Errorf("failed to get frequent comments: %w", err)
+		}
+		comments = found
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error getting comments for %s %s: %s", field.Category, field.Task, err)
+		return nil
+	}
+	completions := make([]Completion, 0, len(comments))
+	for _, comment := range comments {
+		if !strings.HasPrefix(comment, field.Prefix) {
+			continue
+		}
+		completions = append(completions, Completion{Label: comment, Detail: "Comment", Kind: CommentCompletion, Start: field.Start})
+	}
+	return completions
+}
+
+func (self *Service) recentTasks() ([]string, error) {
+	var tasks []string
+	err := self.repository.Transactional(context.TODO(), func(ctx context.Context, repository Saver, queryer Queryer) error {
+		found, err := queryer.RecentTasks(ctx, historyCompletionsLimit)
+		if err != nil {
+			return fmt.Errorf("failed to get recent tasks: %w", err)
+		}
+		for _, task := range found {
+			if self.config().IsTask(task) {
+				tasks = append(tasks, task)
+			}
+		}
+		return nil
+	})
+	return tasks, err
+}
@@ -0,0 +1,35 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/jborkows/timesheets/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldFindFieldAtCursor(t *testing.T) {
+	t.Parallel()
+	parser := workingDayParser()
+	line := "  Category 1.5 Task-1 fixing bugs"
+	cases := []struct {
+		column   int
+		expected model.FieldAtCursor
+	}{
+		{column: 0, expected: model.FieldAtCursor{State: model.StateCategory, Start: 0}},
+		{column: 5, expected: model.FieldAtCursor{State: model.StateCategory, Start: 2, Prefix: "Cat"}},
+		{column: 11, expected: model.FieldAtCursor{State: model.StateHours, Start: 11, Category: "Category"}},
+		{column: 13, expected: model.FieldAtCursor{State: model.StateHours, Start: 11, Prefix: "1.", Category: "Category"}},
+		{column: 19, expected: model.FieldAtCursor{State: model.StateTask, Start: 15, Prefix: "Task", Category: "Category"}},
+		{column: 29, expected: model.FieldAtCursor{State: model.StateComment, Start: 22, Prefix: "fixing ", Category: "Category", Task: "Task-1"}},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, parser.FieldAt(line, c.column), "column %d", c.column)
+	}
+}
+
+func TestShouldTreatWordWhichIsNotTaskAsComment(t *testing.T) {
+	t.Parallel()
+	parser := workingDayParser()
+	field := parser.FieldAt("Category 1.5 fixing bu", 22)
+	assert.Equal(t, model.FieldAtCursor{State: model.StateComment, Start: 13, Prefix: "fixing bu", Category: "Category"}, field)
+}
@@ -2,8 +2,12 @@ package model
 
 import (
 	"errors"
+	"fmt"
 	"io"
+	"sort"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"slices"
 
@@ -14,19 +18,39 @@ type categories struct {
 	Regular  []string
 	Overtime []string
 }
+
+// holidays are fixed MM-DD days of every year, single YYYY-MM-DD days, rules and bundled calendar of the country.
 type holidays struct {
 	Repeatable []string
 	AddHoc     []string
+	Country    string
+	Rules      []holidayRule
 }
 
 type taskDefinition struct {
 	Prefix      string
 	OnlyNumbers bool
 }
+type reportsDefinition struct {
+	Format string
+}
+
+// templateDefinition pre-fills new day files, Holiday is description written on holidays.
+type templateDefinition struct {
+	Lines   []string
+	Holiday string
+}
+
 type Config struct {
 	Categories categories
 	Holidays   holidays
 	Tasks      taskDefinition
+	Reports    reportsDefinition
+	Template   templateDefinition
+	Recurring  []recurringEntry
+	WorkWeek   workWeekDefinition
+	Balance    balanceDefinition
+	Leave      leaveDefinition
 }
 
 func ReadConfig(r io.Reader) (*Config, error) {
@@ -71,22 +95,77 @@ func (config *Config) validate() error {
 			return errors.New("category cannot contain spaces")
 		}
 	}
+	if _, err := ParseReportFormat(config.Reports.Format); err != nil {
+		return err
+	}
+	if _, ok := calendars[config.Holidays.Country]; config.Holidays.Country != "" && !ok {
+		return fmt.Errorf("unknown holiday calendar %q, available: %s", config.Holidays.Country, strings.Join(Countries(), ", "))
+	}
+	for _, rule := range config.Holidays.Rules {
+		if err := rule.validate(); err != nil {
+			return err
+		}
+	}
+	if err := config.WorkWeek.validate(); err != nil {
+		return err
+	}
+	if err := config.Balance.validate(); err != nil {
+		return err
+	}
+	if err := config.Leave.validate(); err != nil {
+		return err
+	}
+	for _, entry := range config.Recurring {
+		if err := entry.validate(config); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// ReportFormat is format of reports shown in editor, text unless configured otherwise.
+func (config *Config) ReportFormat() ReportFormat {
+	format, err := ParseReportFormat(config.Reports.Format)
+	if err != nil {
+		return TextFormat
+	}
+	return format
+}
+
+func (config *Config) Week() *WorkWeek {
+	return config.WorkWeek.workWeek()
+}
+
+// HolidayDescription is written into files of holidays, "Holiday" unless configured otherwise.
+func (config *Config) HolidayDescription() string {
+	if config.Template.Holiday == "" {
+		return "Holiday"
+	}
+	return config.Template.Holiday
+}
+
 func (config *Config) IsHoliday(info *DateInfo) bool {
+	_, ok := config.HolidayName(info)
+	return ok
+}
+
+// HolidayName tells whether the day is a holiday, name is known only for holidays given by rules or calendar.
+func (config *Config) HolidayName(info *DateInfo) (string, bool) {
 	if slices.Contains(config.Holidays.AddHoc, info.Value) {
-		return true
+		return "", true
 	}
-	for _, repeatable := range config.Holidays.Repeatable {
-		if !(len(info.Value) == len("2024-12-24")) {
-			return false
-		}
-		if repeatable == info.Value[5:] {
-			return true
-		}
+	if len(info.Value) != len("2024-12-24") {
+		return "", false
+	}
+	if slices.Contains(config.Holidays.Repeatable, info.Value[5:]) {
+		return "", true
+	}
+	date, err := time.Parse("2006-01-02", info.Value)
+	if err != nil {
+		return "", false
 	}
-	return false
+	rules := append(slices.Clone(calendars[config.Holidays.Country]), config.Holidays.Rules...)
+	return holidayByRules(rules, date)
 }
 
 func insideOfCategory(category string, categories []string) bool {
@@ -110,6 +189,171 @@ func (config *Config) PossibleCategories() []string {
 	return append(config.Categories.Regular, config.Categories.Overtime...)
 }
 
+// ClosestCategories returns at most limit configured categories which resemble text.
+// Categories sharing a prefix with text come first, the rest are ordered by edit distance.
+func (config *Config) ClosestCategories(text string, limit int) []string {
+	type candidate struct {
+		category string
+		prefix   bool
+		distance int
+	}
+	lowered := strings.ToLower(text)
+	maxDistance := max(2, len([]rune(text))/2)
+	var candidates []candidate
+	for _, category := range config.PossibleCategories() {
+		loweredCategory := strings.ToLower(category)
+		prefix := lowered != "" && (strings.HasPrefix(loweredCategory, lowered) || strings.HasPrefix(lowered, loweredCategory))
+		distance := EditDistance(lowered, loweredCategory)
+		if !prefix && distance > maxDistance {
+			continue
+		}
+		candidates = append(candidates, candidate{category: category, prefix: prefix, distance: distance})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].prefix != candidates[j].prefix {
+			return candidates[i].prefix
+		}
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].category < candidates[j].category
+	})
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+	return Map(candidates, func(c candidate) string { return c.category })
+}
+
+// configValue is quoted text assigned to the key of config file, Table is name of enclosing [table] or [[table]].
+type configValue struct {
+	Table string
+	Key   string
+	Value string
+	Occurrence
+}
+
+// closingQuote finds end of text quoted from the index, escaped quotes are skipped in "basic" strings.
+func closingQuote(line string, from int) int {
+	quote := line[from]
+	for i := from + 1; i < len(line); i++ {
+		switch {
+		case quote == '"' && line[i] == '\\':
+			i++
+		case line[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
+
+// configValues finds quoted texts of config file, also inside of arrays spanning lines.
+func configValues(configText string) []configValue {
+	var values []configValue
+	var table, key string
+	depth := 0
+	for lineNumber, line := range strings.Split(configText, "\n") {
+		from := 0
+		if depth == 0 {
+			trimmed := strings.TrimSpace(line)
+			if strings.HasPrefix(trimmed, "[") {
+				header, _, _ := strings.Cut(trimmed, "#")
+				table = strings.TrimSpace(strings.Trim(strings.TrimSpace(header), "[]"))
+				continue
+			}
+			name, _, found := strings.Cut(line, "=")
+			if !found || strings.HasPrefix(trimmed, "#") {
+				continue
+			}
+			key = strings.TrimSpace(name)
+			from = len(name) + 1
+		}
+	scan:
+		for i := from; i < len(line); i++ {
+			switch line[i] {
+			case '#':
+				break scan
+			case '[':
+				depth++
+			case ']':
+				depth--
+			case '"', '\'':
+				end := closingQuote(line, i)
+				if end < 0 {
+					break scan
+				}
+				values = append(values, configValue{
+					Table: table,
+					Key:   key,
+					Value: line[i+1 : end],
+					Occurrence: Occurrence{
+						LineNumber: lineNumber,
+						Start:      utf8.RuneCountInString(line[:i+1]),
+						End:        utf8.RuneCountInString(line[:end]),
+					},
+				})
+				i = end
+			}
+		}
+	}
+	return values
+}
+
+// CategoryOccurrences finds category name of config file among regular and overtime categories,
+// categories of recurring entries and the first words of template lines.
+func CategoryOccurrences(configText string, category string) []Occurrence {
+	var occurrences []Occurrence
+	for _, value := range configValues(configText) {
+		switch {
+		case value.Table == "categories" && (value.Key == "regular" || value.Key == "overtime"),
+			value.Table == "recurring" && value.Key == "category":
+			if value.Value == category {
+				occurrences = append(occurrences, value.Occurrence)
+			}
+		case value.Table == "template" && value.Key == "lines":
+			words := TokenizeFromIndex(value.Value, 0)
+			if len(words) > 0 && words[0].Word == category {
+				occurrences = append(occurrences, Occurrence{
+					LineNumber: value.LineNumber,
+					Start:      value.Start + words[0].Column,
+					End:        value.Start + words[0].EndColumn(),
+				})
+			}
+		}
+	}
+	return occurrences
+}
+
+// withRenamedCategory is copy of the configuration with category renamed in the same places
+// as CategoryOccurrences finds it in config file.
+func (config *Config) withRenamedCategory(oldName string, newName string) *Config {
+	renamed := *config
+	rename := func(names []string) []string {
+		names = slices.Clone(names)
+		for i, name := range names {
+			if name == oldName {
+				names[i] = newName
+			}
+		}
+		return names
+	}
+	renamed.Categories.Regular = rename(config.Categories.Regular)
+	renamed.Categories.Overtime = rename(config.Categories.Overtime)
+	renamed.Recurring = slices.Clone(config.Recurring)
+	for i := range renamed.Recurring {
+		if renamed.Recurring[i].Category == oldName {
+			renamed.Recurring[i].Category = newName
+		}
+	}
+	renamed.Template.Lines = slices.Clone(config.Template.Lines)
+	for i, line := range renamed.Template.Lines {
+		words := TokenizeFromIndex(line, 0)
+		if len(words) > 0 && words[0].Word == oldName {
+			renamed.Template.Lines[i] = line[:words[0].Index] + newName + line[words[0].Index+len(oldName):]
+		}
+	}
+	return &renamed
+}
+
 func (config *Config) RegularCategories() []string {
 	return obtainCategories(config.Categories.Regular)
 }
@@ -2,6 +2,7 @@ package model_test
 
 import (
 	"strings"
+	"time"
 
 	"github.com/jborkows/timesheets/internal/model"
 	"github.com/stretchr/testify/assert"
@@ -99,3 +100,189 @@ addhoc=["2021-02-01"]
 prefix="task-"
 onlyNumbers=true
 `
+
+func TestShouldSuggestClosestCategories(t *testing.T) {
+	t.Parallel()
+	config, _ := model.ReadConfig(strings.NewReader(fakingToml))
+	assert.Equal(t, []string{"categoryA", "categoryB"}, config.ClosestCategories("categoryX", 3))
+	assert.Equal(t, []string{"categoryA"}, config.ClosestCategories("categroyA", 1))
+	assert.Equal(t, []string{"overtimeA"}, config.ClosestCategories("over", 3))
+	assert.Empty(t, config.ClosestCategories("zzz", 3))
+}
+
+func TestShouldFindCategoryInConfigFile(t *testing.T) {
+	t.Parallel()
+	text := `# "dev" is the default one
+[categories]
+regular = ["dev", "meeting"]
+overtime = [
+  'dev-extra', # "dev"
+]
+
+[holidays]
+addHoc = ["dev"]
+
+[template]
+lines = ["meeting 0.25 dev sync", "  dev 1.0"]
+holiday = "dev"
+
+[[recurring]]
+category = "dev"
+comment = "dev"
+`
+	assert.Equal(t, []model.Occurrence{
+		{LineNumber: 2, Start: 12, End: 15},
+		{LineNumber: 11, Start: 37, End: 40},
+		{LineNumber: 15, Start: 12, End: 15},
+	}, model.CategoryOccurrences(text, "dev"))
+	assert.Equal(t, []model.Occurrence{{LineNumber: 4, Start: 3, End: 12}}, model.CategoryOccurrences(text, "dev-extra"))
+	assert.Equal(t, []model.Occurrence{{LineNumber: 2, Start: 19, End: 26}, {LineNumber: 11, Start: 10, End: 17}}, model.CategoryOccurrences(text, "meeting"))
+}
+
+func TestShouldCountColumnsOfCategoryInConfigFileInCharacters(t *testing.T) {
+	t.Parallel()
+	text := `[categories]
+regular = ["wdrożenie", "spotkanie"]
+
+[template]
+lines = ["wdrożenie 1.0 zadanie", "spotkanie 0.25 żółć"]
+`
+	assert.Equal(t, []model.Occurrence{
+		{LineNumber: 1, Start: 25, End: 34},
+		{LineNumber: 4, Start: 35, End: 44},
+	}, model.CategoryOccurrences(text, "spotkanie"))
+	assert.Equal(t, []model.Occurrence{
+		{LineNumber: 1, Start: 12, End: 21},
+		{LineNumber: 4, Start: 10, End: 19},
+	}, model.CategoryOccurrences(text, "wdrożenie"))
+}
+
+func TestShouldReadDayTemplate(t *testing.T) {
+	t.Parallel()
+	config, err := model.ReadConfig(strings.NewReader(fakingToml + `
+[template]
+lines=["categoryA 0.25 daily standup"]
+holiday="Day off"
+`))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"categoryA 0.25 daily standup"}, config.Template.Lines)
+	assert.Equal(t, "Day off", config.HolidayDescription())
+}
+
+func TestHolidayShouldHaveDefaultDescription(t *testing.T) {
+	t.Parallel()
+	config, _ := model.ReadConfig(strings.NewReader(fakingToml))
+	assert.Equal(t, "Holiday", config.HolidayDescription())
+}
+
+func TestShouldReadRecurringEntries(t *testing.T) {
+	t.Parallel()
+	config, err := model.ReadConfig(strings.NewReader(fakingToml + `
+[[recurring]]
+category="categoryA"
+duration="0.25"
+comment="daily standup"
+
+[[recurring]]
+category="categoryB"
+duration="1h"
+task="task-12"
+comment="planning"
+weekdays=["Mon", "thursday"]
+monthdays=[1]
+`))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(config.Recurring))
+	assert.Equal(t, []string{"Mon", "thursday"}, config.Recurring[1].Weekdays)
+	assert.Equal(t, []int{1}, config.Recurring[1].Monthdays)
+}
+
+func TestShouldRejectInvalidRecurringEntries(t *testing.T) {
+	t.Parallel()
+	for _, recurring := range []string{
+		`category="unknown"
+duration="1h"`,
+		`category="categoryA"`,
+		`category="categoryA"
+duration="1h"
+weekdays=["someday"]`,
+		`category="categoryA"
+duration="1h"
+monthdays=[32]`,
+		`category="categoryA"
+duration="1x"`,
+		`category="categoryA"
+duration="1h"
+task="bug-1"`,
+	} {
+		_, err := model.ReadConfig(strings.NewReader(fakingToml + "[[recurring]]\n" + recurring + "\n"))
+		assert.NotNil(t, err, recurring)
+	}
+}
+
+func TestShouldReadWorkWeek(t *testing.T) {
+	t.Parallel()
+	config, err := model.ReadConfig(strings.NewReader(fakingToml + `
+[workweek]
+hours = { mon = 8, tue = 8, wed = 8, thu = 8, fri = 6, sat = 0 }
+weekendOvertime = false
+`))
+	assert.Nil(t, err)
+	week := config.Week()
+	friday, _ := time.Parse("2006-01-02", "2025-03-07")
+	saturday := friday.AddDate(0, 0, 1)
+	assert.Equal(t, model.Minutes(6*60), week.Norm(friday))
+	assert.False(t, week.IsWorkingDay(saturday))
+	assert.False(t, week.IsOvertimeDay(saturday))
+	assert.Equal(t, model.Minutes(38*60), config.RequiredTime(friday.AddDate(0, 0, -4), friday.AddDate(0, 0, 2)))
+}
+
+func TestWorkWeekShouldDefaultToMondayToFriday(t *testing.T) {
+	t.Parallel()
+	config, _ := model.ReadConfig(strings.NewReader(fakingToml))
+	week := config.Week()
+	sunday, _ := time.Parse("2006-01-02", "2025-03-09")
+	assert.Equal(t, model.Minutes(8*60), week.Norm(sunday.AddDate(0, 0, 1)))
+	assert.True(t, week.IsOvertimeDay(sunday))
+}
+
+func TestShouldRejectInvalidWorkWeek(t *testing.T) {
+	t.Parallel()
+	_, err := model.ReadConfig(strings.NewReader(fakingToml + "[workweek]\nhours = { someday = 8 }\n"))
+	assert.NotNil(t, err)
+	_, err = model.ReadConfig(strings.NewReader(fakingToml + "[workweek]\nhours = { mon = 25 }\n"))
+	assert.NotNil(t, err)
+}
+
+func TestRequiredTimeShouldSkipHolidays(t *testing.T) {
+	t.Parallel()
+	config := model.NewConfig([]string{"aaa"}, "Task-")
+	config.Holidays.AddHoc = []string{"2025-03-05"}
+	first, _ := time.Parse("2006-01-02", "2025-03-01")
+	thursday, _ := time.Parse("2006-01-02", "2025-03-06")
+	assert.Equal(t, model.Minutes(160*60), config.RequiredTime(first, first.AddDate(0, 1, -1)))
+	assert.Equal(t, model.Minutes(24*60), config.RequiredTime(first, thursday))
+}
+
+func TestShouldRejectInvalidBalance(t *testing.T) {
+	t.Parallel()
+	_, err := model.ReadConfig(strings.NewReader(fakingToml + "[balance]\nsince = \"2025-13\"\n"))
+	assert.NotNil(t, err)
+	_, err = model.ReadConfig(strings.NewReader(fakingToml + "[balance]\nsince = \"2025-01\"\ncarryOver = -1\n"))
+	assert.NotNil(t, err)
+	config, err := model.ReadConfig(strings.NewReader(fakingToml + "[balance]\nsince = \"2025-01\"\ncarryOver = 40\nexpiresAfter = 3\n"))
+	assert.Nil(t, err)
+	assert.True(t, config.BalanceEnabled())
+}
+
+func TestShouldReadLeave(t *testing.T) {
+	t.Parallel()
+	config, _ := model.ReadConfig(strings.NewReader(fakingToml))
+	assert.True(t, config.IsLeaveKind("sick"))
+	config, err := model.ReadConfig(strings.NewReader(fakingToml + "[leave]\nkinds = [\"vacation\", \"training\"]\nallowance = 26\n"))
+	assert.Nil(t, err)
+	assert.True(t, config.IsLeaveKind("training"))
+	assert.False(t, config.IsLeaveKind("sick"))
+	_, err = model.ReadConfig(strings.NewReader(fakingToml + "[leave]\nkinds = [\"sick\"]\nallowance = 26\n"))
+	assert.NotNil(t, err)
+}
@@ -33,11 +33,64 @@ type WeeklyStatistic struct {
 	Weekly   Statitic
 }
 
+// MonthlyStatistic carries time required in the whole month and up to the date asked for, the same for all categories.
 type MonthlyStatistic struct {
-	Category      string
-	Monthly       Statitic
-	Dirty         Statitic
-	RequiredHours uint8
+	Category       string
+	Monthly        Statitic
+	Dirty          Statitic
+	Required       Minutes
+	RequiredToDate Minutes
+}
+
+// SignedMinutes is difference of times, e.g. balance of worked and required time.
+type SignedMinutes int32
+
+// String shows sign always, e.g. +1:30 or -0:45
+func (m SignedMinutes) String() string {
+	sign := "+"
+	if m < 0 {
+		sign = "-"
+		m = -m
+	}
+	return fmt.Sprintf("%s%d:%02d", sign, m/60, m%60)
+}
+
+// Minutes is amount of time long enough for any reporting period.
+type Minutes uint32
+
+func (m Minutes) Hours() uint32 {
+	return uint32(m) / 60
+}
+
+func (m Minutes) Remainder() uint8 {
+	return uint8(m % 60)
+}
+
+type GroupBy int
+
+const (
+	GroupByCategory GroupBy = iota
+	GroupByTask
+)
+
+func (g GroupBy) String() string {
+	switch g {
+	case GroupByCategory:
+		return "category"
+	case GroupByTask:
+		return "task"
+	default:
+		return "unknown"
+	}
+}
+
+// RangeStatistic sums time spent in arbitrary period, Task is empty when grouped by category.
+type RangeStatistic struct {
+	Category string
+	Task     string
+	Overtime bool
+	Dirty    Minutes
+	Total    Minutes
 }
 
 type Day time.Time
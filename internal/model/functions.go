@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"time"
+	"unicode/utf8"
 )
 
 func Debounce(f func(), delay time.Duration) func() {
@@ -32,6 +33,9 @@ func Debounce2[T any, K any](f func(one T, second K), delay time.Duration) func(
 	}
 }
 
+// timesheetFileLayout places file of the day under project root, e.g. 2025/03/06.tsf
+const timesheetFileLayout = "2006/01/02" + TimeSheetExtension
+
 type DateFromFileNameParams struct {
 	URI         string
 	ProjectRoot string
@@ -47,7 +51,7 @@ func DateFromFile(params DateFromFileNameParams) (time.Time, error) {
 	if err != nil {
 		return time.Time{}, fmt.Errorf("Failed to get relative path: %w", err)
 	}
-	date, err := time.Parse("2006/01/02.tsf", relativePath)
+	date, err := time.Parse(timesheetFileLayout, relativePath)
 	if err != nil {
 		return time.Time{}, fmt.Errorf("Failed to parse date: %w", err)
 	}
@@ -91,9 +95,17 @@ func Map[T any, V any](list []T, f func(T) V) []V {
 	return result
 }
 
+// Token is a word of the line, Index is its byte offset used to slice the line,
+// Column is its position counted in characters, as positions of LSP are.
 type Token struct {
-	Index int
-	Word  string
+	Index  int
+	Column int
+	Word   string
+}
+
+// EndColumn is the column right after the word.
+func (t Token) EndColumn() int {
+	return t.Column + utf8.RuneCountInString(t.Word)
 }
 
 func TokenizeFromIndex(input string, j int) []Token {
@@ -103,6 +115,7 @@ func TokenizeFromIndex(input string, j int) []Token {
 	}
 
 	substr := input[j:]
+	column := utf8.RuneCountInString(input[:j])
 
 	var tokens []Token
 	var temp Token = Token{}
@@ -115,9 +128,11 @@ func TokenizeFromIndex(input string, j int) []Token {
 		} else {
 			if temp.Word == "" {
 				temp.Index = i + j
+				temp.Column = column
 			}
 			temp.Word += string(r)
 		}
+		column++
 	}
 	if temp.Word != "" {
 		tokens = append(tokens, temp)
@@ -125,3 +140,26 @@ func TokenizeFromIndex(input string, j int) []Token {
 
 	return tokens
 }
+
+// EditDistance returns the Levenshtein distance between two strings.
+func EditDistance(first string, second string) int {
+	a := []rune(first)
+	b := []rune(second)
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
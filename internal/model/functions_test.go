@@ -48,3 +48,23 @@ func TestShouldTokenizeTextWithMultipleSpace(t *testing.T) {
 	assert.Equal(t, strings.Index(input, "second"), tokens[2].Index)
 	assert.Equal(t, "second", tokens[2].Word)
 }
+
+func TestShouldCountColumnsOfTokensInCharacters(t *testing.T) {
+	t.Parallel()
+	input := "wdrożenie 1.0 zadanie"
+	tokens := model.TokenizeFromIndex(input, 0)
+	assert.Equal(t, 3, len(tokens))
+	assert.Equal(t, 0, tokens[0].Column)
+	assert.Equal(t, 9, tokens[0].EndColumn())
+	assert.Equal(t, strings.Index(input, "1.0"), tokens[1].Index)
+	assert.Equal(t, 10, tokens[1].Column)
+	assert.Equal(t, 14, tokens[2].Column)
+}
+
+func TestEditDistance(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, 0, model.EditDistance("abc", "abc"))
+	assert.Equal(t, 1, model.EditDistance("abc", "abd"))
+	assert.Equal(t, 2, model.EditDistance("categroy", "category"))
+	assert.Equal(t, 3, model.EditDistance("", "abc"))
+}
@@ -0,0 +1,196 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Ways of moving holiday falling on a weekend to a working day.
+const (
+	// ObservedNext moves it to the next working day which is not a holiday already, e.g. UK bank holidays.
+	ObservedNext = "next"
+	// ObservedNearest moves Saturday to Friday and Sunday to Monday, e.g. US federal holidays.
+	ObservedNearest = "nearest"
+)
+
+// holidayRule is one of: fixed Month and Day, Easter relative (days after Easter Sunday, negative before)
+// or Nth Weekday of Month (-1 for the last one). Since limits rule to years starting with the given one.
+type holidayRule struct {
+	Name     string
+	Month    int
+	Day      int
+	Easter   *int
+	Weekday  string
+	Nth      int
+	Observed string
+	Since    int
+}
+
+// namedDay is a holiday of a particular year.
+type namedDay struct {
+	date time.Time
+	name string
+}
+
+func fixedRule(name string, month int, day int) holidayRule {
+	return holidayRule{Name: name, Month: month, Day: day}
+}
+
+func easterRule(name string, offset int) holidayRule {
+	return holidayRule{Name: name, Easter: &offset}
+}
+
+func nthWeekdayRule(name string, month int, weekday time.Weekday, nth int) holidayRule {
+	return holidayRule{Name: name, Month: month, Weekday: weekday.String(), Nth: nth}
+}
+
+// isWeekend is about Saturday and Sunday as moving holidays follows law, not configured working week.
+func isWeekend(day time.Time) bool {
+	return day.Weekday() == time.Saturday || day.Weekday() == time.Sunday
+}
+
+// easterSunday follows anonymous Gregorian algorithm.
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+func nthWeekday(year int, month int, weekday time.Weekday, nth int) time.Time {
+	if nth < 0 {
+		last := time.Date(year, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC)
+		back := (int(last.Weekday()) - int(weekday) + 7) % 7
+		return last.AddDate(0, 0, -back)
+	}
+	first := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	forward := (int(weekday) - int(first.Weekday()) + 7) % 7
+	return first.AddDate(0, 0, forward+7*(nth-1))
+}
+
+func (rule *holidayRule) validate() error {
+	kinds := 0
+	if rule.Easter != nil {
+		kinds++
+	}
+	if rule.Weekday != "" {
+		kinds++
+		if _, err := parseWeekday(rule.Weekday); err != nil {
+			return fmt.Errorf("holiday %s: %w", rule.Name, err)
+		}
+		if rule.Nth < -1 || rule.Nth == 0 || rule.Nth > 5 {
+			return fmt.Errorf("holiday %s has invalid nth %d, expected 1 to 5 or -1", rule.Name, rule.Nth)
+		}
+	}
+	if rule.Day != 0 {
+		kinds++
+		if rule.Day < 1 || rule.Day > 31 {
+			return fmt.Errorf("holiday %s has invalid day %d", rule.Name, rule.Day)
+		}
+	}
+	if kinds != 1 {
+		return fmt.Errorf("holiday %s should have exactly one of day, easter or weekday", rule.Name)
+	}
+	if rule.Easter == nil && (rule.Month < 1 || rule.Month > 12) {
+		return fmt.Errorf("holiday %s has invalid month %d", rule.Name, rule.Month)
+	}
+	// leap year, so 29th of February is accepted
+	if days := time.Date(2024, time.Month(rule.Month)+1, 0, 0, 0, 0, 0, time.UTC).Day(); rule.Day > days {
+		return fmt.Errorf("holiday %s has invalid day %d of month %d", rule.Name, rule.Day, rule.Month)
+	}
+	if rule.Observed != "" && rule.Observed != ObservedNext && rule.Observed != ObservedNearest {
+		return fmt.Errorf("holiday %s has unknown observed %q, expected %s or %s", rule.Name, rule.Observed, ObservedNext, ObservedNearest)
+	}
+	return nil
+}
+
+// dateIn is the date the rule gives in the year, before moving it from weekend.
+// There is none when the day is missing in the month of the year, e.g. 5th Monday or 29th of February.
+func (rule *holidayRule) dateIn(year int) (time.Time, bool) {
+	if rule.Since > year {
+		return time.Time{}, false
+	}
+	switch {
+	case rule.Easter != nil:
+		return easterSunday(year).AddDate(0, 0, *rule.Easter), true
+	case rule.Weekday != "":
+		weekday, err := parseWeekday(rule.Weekday)
+		if err != nil {
+			return time.Time{}, false
+		}
+		date := nthWeekday(year, rule.Month, weekday, rule.Nth)
+		return date, int(date.Month()) == rule.Month
+	default:
+		date := time.Date(year, time.Month(rule.Month), rule.Day, 0, 0, 0, 0, time.UTC)
+		return date, int(date.Month()) == rule.Month
+	}
+}
+
+// holidaysOf lists holidays given by rules in the year, holidays falling on weekends are also kept on their own date.
+func holidaysOf(rules []holidayRule, year int) []namedDay {
+	type dated struct {
+		rule holidayRule
+		date time.Time
+	}
+	var all []dated
+	taken := make(map[time.Time]bool)
+	for _, rule := range rules {
+		if date, ok := rule.dateIn(year); ok {
+			all = append(all, dated{rule: rule, date: date})
+			taken[date] = true
+		}
+	}
+	sort.SliceStable(all, func(i, j int) bool { return all[i].date.Before(all[j].date) })
+
+	var days []namedDay
+	for _, holiday := range all {
+		days = append(days, namedDay{date: holiday.date, name: holiday.rule.Name})
+		if !isWeekend(holiday.date) {
+			continue
+		}
+		switch holiday.rule.Observed {
+		case ObservedNearest:
+			observed := holiday.date.AddDate(0, 0, 1)
+			if holiday.date.Weekday() == time.Saturday {
+				observed = holiday.date.AddDate(0, 0, -1)
+			}
+			days = append(days, namedDay{date: observed, name: holiday.rule.Name + " (observed)"})
+		case ObservedNext:
+			observed := holiday.date.AddDate(0, 0, 1)
+			for isWeekend(observed) || taken[observed] {
+				observed = observed.AddDate(0, 0, 1)
+			}
+			taken[observed] = true
+			days = append(days, namedDay{date: observed, name: holiday.rule.Name + " (observed)"})
+		}
+	}
+	return days
+}
+
+// holidayByRules finds holiday of the date, observed days may move between years so neighbouring years are checked too.
+func holidayByRules(rules []holidayRule, date time.Time) (string, bool) {
+	if len(rules) == 0 {
+		return "", false
+	}
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	for year := day.Year() - 1; year <= day.Year()+1; year++ {
+		for _, holiday := range holidaysOf(rules, year) {
+			if holiday.date.Equal(day) {
+				return holiday.name, true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,143 @@
+package model_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jborkows/timesheets/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func calendarConfig(t *testing.T, holidays string) *model.Config {
+	config, err := model.ReadConfig(strings.NewReader(`
+[categories]
+regular=["categoryA"]
+[holidays]
+` + holidays))
+	if err != nil {
+		t.Fatalf("Error reading config: %v", err)
+	}
+	return config
+}
+
+func holidayName(config *model.Config, date string) (string, bool) {
+	return config.HolidayName(&model.DateInfo{Value: date})
+}
+
+func TestShouldMatchHolidaysOfBundledCalendars(t *testing.T) {
+	t.Parallel()
+	for _, testCase := range []struct {
+		country string
+		date    string
+		name    string
+	}{
+		{"PL", "2025-04-21", "Poniedziałek Wielkanocny"},
+		{"PL", "2025-06-19", "Boże Ciało"},
+		{"PL", "2025-12-24", "Wigilia"},
+		{"DE", "2025-04-18", "Karfreitag"},
+		{"DE", "2025-05-29", "Christi Himmelfahrt"},
+		{"US", "2025-11-27", "Thanksgiving Day"},
+		{"US", "2025-05-26", "Memorial Day"},
+		{"US", "2021-07-05", "Independence Day (observed)"},
+		{"US", "2021-12-31", "New Year's Day (observed)"},
+		{"GB", "2021-12-27", "Christmas Day (observed)"},
+		{"GB", "2021-12-28", "Boxing Day (observed)"},
+		{"GB", "2025-08-25", "Summer bank holiday"},
+	} {
+		config := calendarConfig(t, `country="`+testCase.country+`"`)
+		name, ok := holidayName(config, testCase.date)
+		assert.True(t, ok, testCase.date)
+		assert.Equal(t, testCase.name, name)
+	}
+}
+
+func TestShouldNotMatchWorkingDaysOfBundledCalendars(t *testing.T) {
+	t.Parallel()
+	for _, testCase := range []struct {
+		country string
+		date    string
+	}{
+		{"PL", "2024-12-24"},
+		{"PL", "2025-04-22"},
+		{"US", "2021-07-06"},
+		{"GB", "2021-12-29"},
+	} {
+		config := calendarConfig(t, `country="`+testCase.country+`"`)
+		_, ok := holidayName(config, testCase.date)
+		assert.False(t, ok, testCase.date)
+	}
+}
+
+func TestShouldMatchConfiguredHolidayRules(t *testing.T) {
+	t.Parallel()
+	config := calendarConfig(t, `
+repeatable=["12-31"]
+[[holidays.rules]]
+name="Easter Tuesday"
+easter=2
+[[holidays.rules]]
+name="Company day"
+month=9
+weekday="fri"
+nth=-1
+[[holidays.rules]]
+name="Founding day"
+month=3
+day=1
+observed="next"
+`)
+	name, ok := holidayName(config, "2025-04-22")
+	assert.True(t, ok)
+	assert.Equal(t, "Easter Tuesday", name)
+	name, _ = holidayName(config, "2025-09-26")
+	assert.Equal(t, "Company day", name)
+	name, _ = holidayName(config, "2025-03-03")
+	assert.Equal(t, "Founding day (observed)", name)
+	name, ok = holidayName(config, "2025-12-31")
+	assert.True(t, ok)
+	assert.Equal(t, "", name)
+	_, ok = holidayName(config, "2025-09-19")
+	assert.False(t, ok)
+}
+
+func TestShouldSkipHolidayRulesMissingInMonth(t *testing.T) {
+	t.Parallel()
+	config := calendarConfig(t, `
+[[holidays.rules]]
+name="Fifth Monday"
+month=2
+weekday="mon"
+nth=5
+[[holidays.rules]]
+name="Leap day"
+month=2
+day=29
+`)
+	name, ok := holidayName(config, "2027-03-01")
+	assert.False(t, ok, name)
+	name, ok = holidayName(config, "2025-03-03")
+	assert.False(t, ok, name)
+	name, ok = holidayName(config, "2025-03-01")
+	assert.False(t, ok, name)
+	name, _ = holidayName(config, "2044-02-29")
+	assert.Equal(t, "Fifth Monday", name)
+	name, _ = holidayName(config, "2024-02-29")
+	assert.Equal(t, "Leap day", name)
+}
+
+func TestShouldRejectInvalidHolidays(t *testing.T) {
+	t.Parallel()
+	for _, holidays := range []string{
+		`country="XX"`,
+		"[[holidays.rules]]\nname=\"none\"\nmonth=5",
+		"[[holidays.rules]]\nname=\"both\"\nmonth=5\nday=1\neaster=1",
+		"[[holidays.rules]]\nname=\"nth\"\nmonth=5\nweekday=\"mon\"\nnth=6",
+		"[[holidays.rules]]\nname=\"month\"\nmonth=13\nday=1",
+		"[[holidays.rules]]\nname=\"day\"\nmonth=2\nday=31",
+		"[[holidays.rules]]\nname=\"day\"\nmonth=4\nday=31",
+		"[[holidays.rules]]\nname=\"observed\"\nmonth=5\nday=1\nobserved=\"later\"",
+	} {
+		_, err := model.ReadConfig(strings.NewReader("[categories]\nregular=[\"categoryA\"]\n[holidays]\n" + holidays + "\n"))
+		assert.NotNil(t, err, holidays)
+	}
+}
@@ -0,0 +1,216 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+var ErrInvalidLeave = errors.New("unknown leave kind")
+
+const (
+	leavePrefix   = "@"
+	halfDay       = "half"
+	vacationLeave = "vacation"
+)
+
+// leaveDefinition lists kinds of absence written as @kind, Allowance is number of vacation days in a year.
+type leaveDefinition struct {
+	Kinds     []string
+	Allowance float64
+}
+
+func defaultLeaveKinds() []string {
+	return []string{vacationLeave, "sick", "unpaid"}
+}
+
+func (definition *leaveDefinition) validate() error {
+	for _, kind := range definition.Kinds {
+		if kind == "" || strings.ContainsAny(kind, " "+leavePrefix) {
+			return fmt.Errorf("leave: invalid kind %q", kind)
+		}
+	}
+	if definition.Allowance < 0 {
+		return fmt.Errorf("leave: invalid allowance %v", definition.Allowance)
+	}
+	if definition.Allowance > 0 && len(definition.Kinds) > 0 && !slices.Contains(definition.Kinds, vacationLeave) {
+		return fmt.Errorf("leave: allowance requires %q kind", vacationLeave)
+	}
+	return nil
+}
+
+// LeaveKinds are kinds of absence, vacation, sick and unpaid unless configured otherwise.
+func (config *Config) LeaveKinds() []string {
+	if len(config.Leave.Kinds) == 0 {
+		return defaultLeaveKinds()
+	}
+	return config.Leave.Kinds
+}
+
+func (config *Config) IsLeaveKind(text string) bool {
+	return slices.Contains(config.LeaveKinds(), text)
+}
+
+// Leave is absence during the day, e.g. "@vacation", "@sick 4h flu" or "@vacation half".
+// Without duration it takes the whole norm of the day.
+type Leave struct {
+	Kind    string
+	Time    Minutes
+	Comment string
+}
+
+func (l *Leave) IsHoliday() bool {
+	return false
+}
+
+// TakenLeave is saved leave of the day.
+type TakenLeave struct {
+	Date Day
+	Leave
+}
+
+func isLeaveLine(line string) bool {
+	return strings.HasPrefix(strings.TrimSpace(line), leavePrefix)
+}
+
+// parseLeave reads "@kind [duration|half] [comment]", duration is written the same way as time of entries
+// and defaults to norm of the day. Word starting with a digit must be a valid duration.
+func (parser *Parser) parseLeave(line string, dateInfo DateInfo) (WorkItem, error) {
+	words := lineWords(line)
+	kind := strings.TrimPrefix(words[0].Text, leavePrefix)
+	if !parser.IsLeaveKind(kind) {
+		return nil, &ParseError{
+			Err:      ErrInvalidLeave,
+			Kind:     CategoryErrorKind,
+			Start:    words[0].start,
+			End:      words[0].end,
+			Severity: SeverityError,
+		}
+	}
+	leave := &Leave{Kind: kind, Time: parser.DayNorm(&dateInfo)}
+	rest := words[1:]
+	if len(rest) > 0 {
+		if rest[0].Text == halfDay {
+			leave.Time /= 2
+			rest = rest[1:]
+		} else if startsWithDigit(rest[0].Text) {
+			var spent TimesheetEntry
+			if !readTime(rest[0].tokens, &spent) || spent.Hours == 0 && spent.Minutes == 0 {
+				return nil, &ParseError{
+					Err:      ErrInvalidTime,
+					Kind:     TimeErrorKind,
+					Start:    rest[0].start,
+					End:      rest[0].end,
+					Severity: SeverityError,
+				}
+			}
+			leave.Time = minutesOf(spent.Hours, spent.Minutes)
+			rest = rest[1:]
+		}
+	}
+	if len(rest) > 0 {
+		leave.Comment = strings.TrimSpace(string([]rune(line)[rest[0].start:]))
+	}
+	return leave, nil
+}
+
+func startsWithDigit(text string) bool {
+	return text != "" && text[0] >= '0' && text[0] <= '9'
+}
+
+func (t *Timesheet) AddLeave(leave *Leave) {
+	t.Entries = append(t.Entries, leave)
+}
+
+func (t *Timesheet) leaveTime() Minutes {
+	var total Minutes
+	for _, entry := range t.Entries {
+		if leave, ok := entry.(*Leave); ok {
+			total += leave.Time
+		}
+	}
+	return total
+}
+
+func dateKey(date time.Time) string {
+	return date.Format("2006-01-02")
+}
+
+// requiredTime is time required by the calendar from the first to the last day, inclusive, less saved leave.
+// Leave does not lower requirement of a day below zero.
+func (self *Service) requiredTime(ctx context.Context, queryer Queryer, from time.Time, to time.Time) (Minutes, error) {
+	leaves, err := queryer.Leaves(ctx, Day(from), Day(to))
+	if err != nil {
+		return 0, err
+	}
+	taken := make(map[string]Minutes, len(leaves))
+	for _, leave := range leaves {
+		taken[dateKey(time.Time(leave.Date))] += leave.Time
+	}
+	var total Minutes
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		required := self.config().RequiredTime(day, day)
+		total += required - min(required, taken[dateKey(day)])
+	}
+	return total, nil
+}
+
+// LeaveAllowance tells how many days of vacation were planned or taken in the year.
+type LeaveAllowance struct {
+	Days float64
+	Used float64
+}
+
+func (a *LeaveAllowance) Remaining() float64 {
+	return a.Days - a.Used
+}
+
+// LeaveStatistics sums saved leave of the month of the date by kind.
+func (self *Service) LeaveStatistics(date time.Time) ([]ReportRow, error) {
+	month := TimesheetForDate(date).Month()
+	leaves, err := statistics(self, date, func(ctx context.Context, queryer Queryer, date time.Time) ([]TakenLeave, error) {
+		return queryer.Leaves(ctx, month.BeginDate, month.EndDate)
+	})
+	if err != nil {
+		return nil, err
+	}
+	byKind := make(map[string]Minutes)
+	for _, leave := range leaves {
+		byKind[leave.Kind] += leave.Time
+	}
+	rows := make([]ReportRow, 0, len(byKind))
+	for kind, spent := range byKind {
+		rows = append(rows, ReportRow{Category: kind, Time: spent})
+	}
+	sortRows(rows)
+	return rows, nil
+}
+
+// VacationAllowance counts vacation saved in the year of the date in days, leave shorter than norm of the day
+// is a part of the day. It is nil when allowance is not configured.
+func (self *Service) VacationAllowance(date time.Time) (*LeaveAllowance, error) {
+	if self.config().Leave.Allowance == 0 {
+		return nil, nil
+	}
+	first := time.Date(date.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(1, 0, -1)
+	leaves, err := statistics(self, date, func(ctx context.Context, queryer Queryer, date time.Time) ([]TakenLeave, error) {
+		return queryer.Leaves(ctx, Day(first), Day(last))
+	})
+	if err != nil {
+		return nil, err
+	}
+	week := self.config().Week()
+	allowance := &LeaveAllowance{Days: self.config().Leave.Allowance}
+	for _, leave := range leaves {
+		norm := week.Norm(time.Time(leave.Date))
+		if leave.Kind != vacationLeave || norm == 0 {
+			continue
+		}
+		allowance.Used += float64(min(leave.Time, norm)) / float64(norm)
+	}
+	return allowance, nil
+}
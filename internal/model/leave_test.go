@@ -0,0 +1,101 @@
+package model_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jborkows/timesheets/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func leaveParser() model.Parser {
+	parser := workingDayParser()
+	parser.IsLeaveKind = func(text string) bool { return text == "vacation" || text == "sick" }
+	parser.DayNorm = func(a *model.DateInfo) model.Minutes { return 8 * 60 }
+	return parser
+}
+
+func parseLeave(t *testing.T, line string) *model.Leave {
+	parser := leaveParser()
+	item, err := parser.ParseLine(aDate())(line)
+	if err != nil {
+		t.Fatalf("Error parsing line: %v", err)
+	}
+	leave, ok := item.(*model.Leave)
+	if !ok {
+		t.Fatalf("Expected Leave, got %T", item)
+	}
+	return leave
+}
+
+func TestLeaveWithoutDurationShouldTakeWholeDay(t *testing.T) {
+	t.Parallel()
+	leave := parseLeave(t, "@vacation")
+	assert.Equal(t, model.Leave{Kind: "vacation", Time: 8 * 60}, *leave)
+	assert.False(t, leave.IsHoliday())
+}
+
+func TestShouldParseLeaveDurations(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, model.Leave{Kind: "sick", Time: 4 * 60, Comment: "flu"}, *parseLeave(t, "@sick 4h flu"))
+	assert.Equal(t, model.Leave{Kind: "vacation", Time: 4 * 60}, *parseLeave(t, "@vacation half"))
+	assert.Equal(t, model.Leave{Kind: "sick", Time: 90, Comment: "doctor visit"}, *parseLeave(t, "  @sick 1.5 doctor visit"))
+	assert.Equal(t, model.Leave{Kind: "sick", Time: 150}, *parseLeave(t, "@sick 2h30m"))
+	assert.Equal(t, model.Leave{Kind: "vacation", Time: 8 * 60, Comment: "trip"}, *parseLeave(t, "@vacation trip"))
+}
+
+func TestLeaveShouldAcceptTimeRange(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, model.Leave{Kind: "sick", Time: 4 * 60, Comment: "dentist"}, *parseLeave(t, "@sick 09:00-13:00 dentist"))
+	assert.Equal(t, model.Leave{Kind: "sick", Time: 4 * 60, Comment: "flu"}, *parseLeave(t, "@sick 4 flu"))
+}
+
+func TestInvalidLeaveDurationShouldBeReported(t *testing.T) {
+	t.Parallel()
+	parser := leaveParser()
+	for _, duration := range []string{"4hr", "4,5", "25h", "0"} {
+		_, err := parser.ParseLine(aDate())("@sick " + duration + " flu")
+		var parseError *model.ParseError
+		if assert.True(t, errors.As(err, &parseError), duration) {
+			assert.Equal(t, model.ErrInvalidTime, parseError.Err, duration)
+			assert.Equal(t, model.TimeErrorKind, parseError.Kind, duration)
+			assert.Equal(t, len("@sick "), parseError.Start, duration)
+			assert.Equal(t, len("@sick ")+len(duration), parseError.End, duration)
+		}
+	}
+}
+
+func TestLeaveErrorsShouldCountColumnsInCharacters(t *testing.T) {
+	t.Parallel()
+	parser := leaveParser()
+	parser.IsLeaveKind = func(text string) bool { return text == "żłobek" }
+	item, err := parser.ParseLine(aDate())("@żłobek 1h zamknięty")
+	assert.Nil(t, err)
+	assert.Equal(t, &model.Leave{Kind: "żłobek", Time: 60, Comment: "zamknięty"}, item)
+
+	_, err = parser.ParseLine(aDate())("@żłobek 4hr zamknięty")
+	var parseError *model.ParseError
+	assert.True(t, errors.As(err, &parseError))
+	assert.Equal(t, len([]rune("@żłobek ")), parseError.Start)
+	assert.Equal(t, len([]rune("@żłobek 4hr")), parseError.End)
+}
+
+func TestUnknownLeaveKindIsInvalid(t *testing.T) {
+	t.Parallel()
+	parser := leaveParser()
+	_, err := parser.ParseLine(aDate())("@party 2h")
+	var parseError *model.ParseError
+	assert.True(t, errors.As(err, &parseError))
+	assert.Equal(t, model.ErrInvalidLeave, parseError.Err)
+	assert.Equal(t, 0, parseError.Start)
+	assert.Equal(t, len("@party"), parseError.End)
+}
+
+func TestLeaveShouldLowerPotentialWorkingTime(t *testing.T) {
+	t.Parallel()
+	timesheet, _ := model.NewTimesheet("2025-03-04")
+	timesheet.AddLeave(&model.Leave{Kind: "sick", Time: 3 * 60})
+	assert.Equal(t, model.Minutes(5*60), timesheet.PotentialWorkingTime(model.DefaultWorkWeek()))
+	timesheet.AddLeave(&model.Leave{Kind: "vacation", Time: 8 * 60})
+	assert.Equal(t, model.Minutes(0), timesheet.PotentialWorkingTime(model.DefaultWorkWeek()))
+}
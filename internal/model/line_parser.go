@@ -4,21 +4,78 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"runtime/debug"
 	"strconv"
 	"strings"
+	"unicode"
 )
 
 var (
-	ErrEmptyLine       = errors.New("empty line")
-	ErrInvalidCategory = errors.New("invalid category")
-	ErrInvalidTime     = errors.New("invalid time format. Use X.Y or XhYm (e.g., 1.5 or 1h30m)")
+	ErrEmptyLine         = errors.New("empty line")
+	ErrInvalidCategory   = errors.New("invalid category")
+	ErrInvalidTime       = errors.New("invalid time format. Use X.Y, XhYm or HH:MM-HH:MM (e.g., 1.5, 1h30m or 09:15-10:45)")
+	ErrLongEntry         = errors.New("entry is longer than 8 hours")
+	ErrAmbiguousFraction = errors.New("ambiguous fraction of hour")
+	ErrOverlappingRange  = errors.New("time range overlaps")
+	ErrUnaccountedGap    = errors.New("unaccounted time")
 )
 
+const longEntryThreshold = 8
+
+type ErrorKind int
+
+const (
+	CategoryErrorKind ErrorKind = iota
+	TimeErrorKind
+	EntryErrorKind
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case CategoryErrorKind:
+		return "category"
+	case TimeErrorKind:
+		return "time"
+	case EntryErrorKind:
+		return "entry"
+	default:
+		return "unknown"
+	}
+}
+
+// Severity follows numbering of LSP diagnostic severities.
+type Severity int
+
+const (
+	SeverityError Severity = iota + 1
+	SeverityWarning
+	SeverityInformation
+	SeverityHint
+)
+
+// ParseError points to the part of line (columns Start to End) which caused Err.
+type ParseError struct {
+	Err      error
+	Kind     ErrorKind
+	Start    int
+	End      int
+	Severity Severity
+}
+
+func (e *ParseError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Parser reads lines of a day, DayNorm gives time of leave written without duration.
 type Parser struct {
 	HolidayClassifier HolidayClassifier
 	IsCategory        func(text string) bool
 	IsTask            func(text string) bool
+	IsLeaveKind       func(text string) bool
+	DayNorm           func(aDate *DateInfo) Minutes
 }
 
 func (parser *Parser) ParseLine(dateInfo DateInfo) func(line string) (WorkItem, error) {
@@ -27,19 +84,25 @@ func (parser *Parser) ParseLine(dateInfo DateInfo) func(line string) (WorkItem,
 			return NewHoliday(dateInfo.Value, line)
 		}
 	}
-	return parser.doParseLine
+	return func(line string) (WorkItem, error) {
+		if isLeaveLine(line) {
+			return parser.parseLeave(line, dateInfo)
+		}
+		return parser.doParseLine(line)
+	}
 }
 
-type analyzerState int
+// AnalyzerState names field of the line which is being read.
+type AnalyzerState int
 
 const (
-	StateCategory analyzerState = iota
+	StateCategory AnalyzerState = iota
 	StateHours
 	StateTask
 	StateComment
 )
 
-func (s analyzerState) String() string {
+func (s AnalyzerState) String() string {
 	switch s {
 	case StateCategory:
 		return "category"
@@ -57,11 +120,13 @@ func (s analyzerState) String() string {
 type tokenAnalyzer struct {
 	*Parser
 	tokens []token
-	state  analyzerState
+	state  AnalyzerState
 	entry  *TimesheetEntry
+	last   span
 }
 
 func (analyzer *tokenAnalyzer) analyze(t token) (err error) {
+	analyzer.last = t.bounds()
 	switch analyzer.state {
 	case StateCategory:
 		return analyzer.analizeCategory(t)
@@ -80,26 +145,55 @@ func (analyzer *tokenAnalyzer) analyze(t token) (err error) {
 func (analyzer *tokenAnalyzer) finish() (*TimesheetEntry, error) {
 	switch analyzer.state {
 	case StateCategory:
-		if analyzer.IsCategory(analyzer.entry.Category) {
-
-		} else {
-			return nil, ErrInvalidCategory
+		if analyzer.IsCategory(joinTokens(analyzer.tokens)) {
+			return nil, analyzer.missingTime()
 		}
+		return nil, analyzer.invalidCategory()
 	case StateHours:
-		err := analyzer.analizeHours(&space{})
+		err := analyzer.analizeHours(&space{span: span{start: analyzer.last.end, end: analyzer.last.end}})
 		if err != nil {
 			return nil, err
 		}
 		return analyzer.entry, nil
 	}
-	var commentBuilder strings.Builder
-	for _, t := range analyzer.tokens {
-		commentBuilder.WriteString(t.value())
-	}
-	analyzer.entry.Comment = strings.TrimSpace(commentBuilder.String())
+	analyzer.entry.Comment = strings.TrimSpace(joinTokens(analyzer.tokens))
 	return analyzer.entry, nil
 }
 
+func joinTokens(tokens []token) string {
+	var builder strings.Builder
+	for _, t := range tokens {
+		builder.WriteString(t.value())
+	}
+	return builder.String()
+}
+
+// pending returns span covering collected tokens, or last seen token if nothing was collected.
+func (analyzer *tokenAnalyzer) pending() span {
+	if len(analyzer.tokens) == 0 {
+		return analyzer.last
+	}
+	return span{
+		start: analyzer.tokens[0].bounds().start,
+		end:   analyzer.tokens[len(analyzer.tokens)-1].bounds().end,
+	}
+}
+
+func (analyzer *tokenAnalyzer) invalidCategory() error {
+	at := analyzer.pending()
+	return &ParseError{Err: ErrInvalidCategory, Kind: CategoryErrorKind, Start: at.start, End: at.end, Severity: SeverityError}
+}
+
+func (analyzer *tokenAnalyzer) invalidTime() error {
+	at := analyzer.pending()
+	return &ParseError{Err: ErrInvalidTime, Kind: TimeErrorKind, Start: at.start, End: at.end, Severity: SeverityError}
+}
+
+func (analyzer *tokenAnalyzer) missingTime() error {
+	end := analyzer.last.end
+	return &ParseError{Err: ErrInvalidTime, Kind: TimeErrorKind, Start: end, End: end, Severity: SeverityError}
+}
+
 func (analyzer *tokenAnalyzer) analizeTask(t token) error {
 	if word, ok := t.(*word); ok {
 		if analyzer.IsTask(word.Value) {
@@ -117,19 +211,15 @@ func (analyzer *tokenAnalyzer) analizeTask(t token) error {
 func (analyzer *tokenAnalyzer) analizeCategory(t token) error {
 	if _, ok := t.(*space); ok {
 		if len(analyzer.tokens) == 0 {
-			return ErrInvalidCategory
-		}
-		var categoryBuilder strings.Builder
-		for _, tt := range analyzer.tokens {
-			categoryBuilder.WriteString(tt.value())
+			return analyzer.invalidCategory()
 		}
-		potentialCategory := categoryBuilder.String()
+		potentialCategory := joinTokens(analyzer.tokens)
 		if analyzer.IsCategory(potentialCategory) {
 			analyzer.entry.Category = potentialCategory
 			analyzer.state = StateHours
 			analyzer.resetTemp()
 		} else {
-			return ErrInvalidCategory
+			return analyzer.invalidCategory()
 		}
 
 	} else {
@@ -142,96 +232,213 @@ func (analyzer *tokenAnalyzer) resetTemp() {
 	analyzer.tokens = nil
 }
 
-func invalidTime() error {
-	debug.PrintStack()
-	return ErrInvalidTime
+func (analyzer *tokenAnalyzer) analizeHours(t token) error {
+	if _, ok := t.(*space); !ok {
+		analyzer.tokens = append(analyzer.tokens, t)
+		return nil
+	}
+	if !readTime(analyzer.tokens, analyzer.entry) {
+		return analyzer.invalidTime()
+	}
+	analyzer.resetTemp()
+	analyzer.state = StateTask
+	return nil
 }
 
-func (analyzer *tokenAnalyzer) analizeHours(t token) error {
-	temp := analyzer.tokens
-	entry := analyzer.entry
-	if _, ok := t.(*space); ok {
-		if len(temp) == 0 {
-			return invalidTime()
-		}
-		if len(temp) == 1 {
-			tt, ok := temp[0].(*number)
-			if ok {
-				if tt.Value >= 24 {
-					return invalidTime()
-				}
-				entry.Hours = uint8(tt.Value)
-				analyzer.resetTemp()
-				return nil
+// readTime sets time of the entry from tokens of a single word, e.g. 4, 4.5, 3h30m or 09:00-13:00.
+// It is false when the word is not a valid time.
+func readTime(temp []token, entry *TimesheetEntry) bool {
+	if len(temp) == 1 {
+		tt, ok := temp[0].(*number)
+		if ok {
+			if tt.Value >= 24 {
+				return false
 			}
-			word, ok := temp[0].(*word)
-			if !ok {
-				return invalidTime()
-			}
-			var timeBuilder strings.Builder
-			for _, leter := range word.Value {
-
-				if leter >= '0' && leter <= '9' {
-					timeBuilder.WriteRune(leter)
-				} else if leter == 'h' {
-					if timeBuilder.Len() == 0 {
-						return invalidTime()
-					}
-					tempValue := parseNumber([]rune(timeBuilder.String()))
-
-					if tempValue >= uint64(24) {
-						return invalidTime()
-					}
-					entry.Hours = uint8(tempValue)
-					timeBuilder.Reset()
-				} else if leter == 'm' {
-					if timeBuilder.Len() == 0 {
-						return invalidTime()
-					}
-					tempValue := parseNumber([]rune(timeBuilder.String()))
-					if tempValue >= uint64(60) {
-						return invalidTime()
-					}
-					entry.Minutes = uint8(tempValue)
-				} else {
-					return invalidTime()
-				}
-			}
-			analyzer.resetTemp()
-			analyzer.state = StateTask
-			return nil
+			entry.Hours = uint8(tt.Value)
+			return true
 		}
-		if len(temp) == 3 {
-			hours, ok := temp[0].(*number)
-			if !ok {
-				return invalidTime()
-			}
-			_, ok = temp[1].(*dot)
-			if !ok {
-				return invalidTime()
-			}
-			minutes, ok := temp[2].(*number)
-			if !ok {
-				return invalidTime()
-			}
-			if hours.Value >= 24 || minutes.Value >= 100 {
-				return invalidTime()
+		word, ok := temp[0].(*word)
+		if !ok {
+			return false
+		}
+		if strings.Contains(word.Value, ":") {
+			timeRange, err := parseTimeRange(word.Value)
+			if err != nil {
+				return false
 			}
-			entry.Hours = uint8(hours.Value)
-			if minutes.Value < 10 {
-				entry.Minutes = uint8(minutes.Value * 6)
+			duration := timeRange.Duration()
+			entry.Range = timeRange
+			entry.Hours = uint8(duration / 60)
+			entry.Minutes = uint8(duration % 60)
+			return true
+		}
+		var timeBuilder strings.Builder
+		for _, leter := range word.Value {
+
+			if leter >= '0' && leter <= '9' {
+				timeBuilder.WriteRune(leter)
+			} else if leter == 'h' {
+				if timeBuilder.Len() == 0 {
+					return false
+				}
+				tempValue := parseNumber([]rune(timeBuilder.String()))
+
+				if tempValue >= uint64(24) {
+					return false
+				}
+				entry.Hours = uint8(tempValue)
+				timeBuilder.Reset()
+			} else if leter == 'm' {
+				if timeBuilder.Len() == 0 {
+					return false
+				}
+				tempValue := parseNumber([]rune(timeBuilder.String()))
+				if tempValue >= uint64(60) {
+					return false
+				}
+				entry.Minutes = uint8(tempValue)
 			} else {
-				entry.Minutes = uint8(minutes.Value * 3 / 5)
+				return false
 			}
-			analyzer.state = StateTask
-			analyzer.resetTemp()
-			return nil
 		}
-		return invalidTime()
-	} else {
-		analyzer.tokens = append(temp, t)
+		return true
 	}
-	return nil
+	if len(temp) == 3 {
+		hours, ok := temp[0].(*number)
+		if !ok {
+			return false
+		}
+		_, ok = temp[1].(*dot)
+		if !ok {
+			return false
+		}
+		minutes, ok := temp[2].(*number)
+		if !ok {
+			return false
+		}
+		if hours.Value >= 24 || minutes.Value >= 100 {
+			return false
+		}
+		entry.Hours = uint8(hours.Value)
+		if minutes.Value < 10 {
+			entry.Minutes = uint8(minutes.Value * 6)
+		} else {
+			entry.Minutes = uint8(minutes.Value * 3 / 5)
+		}
+		return true
+	}
+	return false
+}
+
+// lineWord is a word of the line with tokens it consists of, Text is taken from the line
+// as numbers of tokens drop leading zeros.
+type lineWord struct {
+	span
+	Text   string
+	tokens []token
+}
+
+// lineWords splits line into words, columns are counted the same way as by the entry parser.
+func lineWords(line string) []lineWord {
+	var words []lineWord
+	afterSpace := true
+	for _, t := range tokenize(line, 0) {
+		if _, ok := t.(*space); ok {
+			afterSpace = true
+			continue
+		}
+		if afterSpace {
+			words = append(words, lineWord{span: t.bounds()})
+			afterSpace = false
+		}
+		word := &words[len(words)-1]
+		word.end = t.bounds().end
+		word.tokens = append(word.tokens, t)
+	}
+	runes := []rune(line)
+	for i := range words {
+		words[i].Text = string(runes[words[i].start:words[i].end])
+	}
+	return words
+}
+
+// timeWord returns the word holding time of the entry.
+func timeWord(line string) (lineWord, bool) {
+	words := lineWords(line)
+	if len(words) < 2 {
+		return lineWord{}, false
+	}
+	return words[1], true
+}
+
+// invalidEntry points entry validation error at the time of the entry.
+func invalidEntry(line string, err error) error {
+	at, ok := timeWord(line)
+	if !ok {
+		return err
+	}
+	return &ParseError{Err: err, Kind: EntryErrorKind, Start: at.start, End: at.end, Severity: SeverityError}
+}
+
+// entryWarnings reports problems of a correctly parsed entry which do not prevent saving it.
+func entryWarnings(line string, entry *TimesheetEntry) []*ParseError {
+	at, ok := timeWord(line)
+	if !ok {
+		return nil
+	}
+	word := at.Text
+	start := at.start
+	end := at.end
+	var warnings []*ParseError
+	if entry.Hours > longEntryThreshold || (entry.Hours == longEntryThreshold && entry.Minutes > 0) {
+		warnings = append(warnings, &ParseError{Err: ErrLongEntry, Kind: TimeErrorKind, Start: start, End: end, Severity: SeverityWarning})
+	}
+	if _, fraction, found := strings.Cut(word, "."); found && len(fraction) == 2 && fraction[0] == '0' {
+		warnings = append(warnings, &ParseError{
+			Err:      fmt.Errorf("%w: %s is read as %d:%02d", ErrAmbiguousFraction, word, entry.Hours, entry.Minutes),
+			Kind:     TimeErrorKind,
+			Start:    start,
+			End:      end,
+			Severity: SeverityHint,
+		})
+	}
+	return warnings
+}
+
+// parseTimeRange reads clock range such as 09:15-10:45.
+func parseTimeRange(text string) (*TimeRange, error) {
+	from, to, found := strings.Cut(text, "-")
+	if !found {
+		return nil, ErrInvalidTime
+	}
+	start, err := parseClock(from)
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseClock(to)
+	if err != nil {
+		return nil, err
+	}
+	if end <= start {
+		return nil, ErrInvalidTime
+	}
+	return &TimeRange{StartMinute: start, EndMinute: end}, nil
+}
+
+func parseClock(text string) (uint16, error) {
+	hours, minutes, found := strings.Cut(text, ":")
+	if !found || len(hours) == 0 || len(hours) > 2 || len(minutes) != 2 {
+		return 0, ErrInvalidTime
+	}
+	hour, err := strconv.ParseUint(hours, 10, 8)
+	if err != nil || hour >= 24 {
+		return 0, ErrInvalidTime
+	}
+	minute, err := strconv.ParseUint(minutes, 10, 8)
+	if err != nil || minute >= 60 {
+		return 0, ErrInvalidTime
+	}
+	return uint16(hour*60 + minute), nil
 }
 
 func (parser *Parser) doParseLine(line string) (WorkItem, error) {
@@ -240,7 +447,8 @@ func (parser *Parser) doParseLine(line string) (WorkItem, error) {
 		return nil, ErrEmptyLine
 	}
 	var debugMessage strings.Builder
-	tokens := tokenize(trimmedLine)
+	offset := len([]rune(line)) - len([]rune(strings.TrimLeftFunc(line, unicode.IsSpace)))
+	tokens := tokenize(trimmedLine, offset)
 	for _, t := range tokens {
 		debugMessage.WriteString(t.represent())
 		debugMessage.WriteString(",")
@@ -272,20 +480,29 @@ func parseNumber(temp []rune) uint64 {
 	return value
 }
 
-func tokenize(line string) []token {
+// tokenize splits line into tokens, columns of tokens are shifted by offset.
+func tokenize(line string, offset int) []token {
 	var tokens []token = make([]token, 0)
 	var lineAsRunes = []rune(line)
 	var temp []rune
+	var lastSpace *space
+	tempStart := 0
 	kind := ""
+	flush := func(end int) {
+		at := span{start: offset + tempStart, end: offset + end}
+		if kind == "number" {
+			tokens = append(tokens, &number{span: at, Value: parseNumber(temp)})
+		} else if kind == "word" {
+			tokens = append(tokens, &word{span: at, Value: string(temp)})
+		}
+	}
 
 	for i := range lineAsRunes {
 		if lineAsRunes[i] >= '0' && lineAsRunes[i] <= '9' {
-			if kind == "" {
-				kind = "number"
-				temp = []rune{lineAsRunes[i]}
-			} else if kind == "space" {
+			if kind == "" || kind == "space" {
 				kind = "number"
 				temp = []rune{lineAsRunes[i]}
+				tempStart = i
 			} else {
 				temp = append(temp, lineAsRunes[i])
 			}
@@ -293,61 +510,65 @@ func tokenize(line string) []token {
 		}
 		if lineAsRunes[i] == ' ' {
 			if kind == "space" {
+				lastSpace.end = offset + i + 1
 				continue
 			}
-			if kind == "number" {
-				tokens = append(tokens, &number{Value: parseNumber(temp)})
-			} else if kind == "word" {
-				tokens = append(tokens, &word{Value: string(temp)})
-			}
+			flush(i)
 			temp = []rune{}
 			kind = "space"
-			tokens = append(tokens, &space{})
+			lastSpace = &space{span: span{start: offset + i, end: offset + i + 1}}
+			tokens = append(tokens, lastSpace)
 			continue
 		}
 		if lineAsRunes[i] == '.' {
-			if kind == "number" {
-				tokens = append(tokens, &number{Value: parseNumber(temp)})
-			} else if kind == "word" {
-				tokens = append(tokens, &word{Value: string(temp)})
-			}
-			tokens = append(tokens, &dot{})
+			flush(i)
+			tokens = append(tokens, &dot{span: span{start: offset + i, end: offset + i + 1}})
 			temp = []rune{}
 			kind = ""
 			continue
 		}
-		if kind == "" {
-			temp = []rune{lineAsRunes[i]}
-		} else if kind == "space" {
+		if kind == "" || kind == "space" {
 			temp = []rune{lineAsRunes[i]}
+			tempStart = i
 		} else {
 			temp = append(temp, lineAsRunes[i])
 		}
 		kind = "word"
 	}
-	if kind == "number" {
-		tokens = append(tokens, &number{Value: parseNumber(temp)})
-	} else if kind == "word" {
-		tokens = append(tokens, &word{Value: string(temp)})
-	}
+	flush(len(lineAsRunes))
 
 	return tokens
 }
 
+// span marks columns [start, end) of token within line.
+type span struct {
+	start int
+	end   int
+}
+
+func (s *span) bounds() span {
+	return *s
+}
+
 type token interface {
 	represent() string
 	value() string
+	bounds() span
 }
 type space struct {
+	span
 }
 
 type word struct {
+	span
 	Value string
 }
 type number struct {
+	span
 	Value uint64
 }
 type dot struct {
+	span
 }
 
 func (s *space) represent() string {
@@ -70,7 +70,7 @@ func TestOnlyWrongCategory(t *testing.T) {
 	parser := workingDayParser()
 	timesheet, err := parser.ParseLine(aDate())("Cate ")
 	assert.Nil(t, timesheet)
-	assert.Equal(t, model.ErrInvalidCategory, err)
+	assert.ErrorIs(t, err, model.ErrInvalidCategory)
 }
 
 func TestValidHourShouldNoBeReportedAsBothZeros(t *testing.T) {
@@ -117,6 +117,20 @@ func TestShouldAllowSingleHour(t *testing.T) {
 	assert.Equal(t, uint8(0), valued.Minutes)
 }
 
+func TestShouldAllowWholeHoursFollowedByTask(t *testing.T) {
+	t.Parallel()
+
+	parser := workingDayParser()
+	timesheet, err := parser.ParseLine(aDate())("Category 2 Task-123 description")
+	if err != nil {
+		t.Fatalf("Error parsing line: %v", err)
+	}
+	valued := timesheet.(*model.TimesheetEntry)
+	assert.Equal(t, uint8(2), valued.Hours)
+	assert.Equal(t, "Task-123", *valued.Task)
+	assert.Equal(t, "description", valued.Comment)
+}
+
 func TestShouldAllowSingleMinutes(t *testing.T) {
 	t.Parallel()
 
@@ -161,7 +175,7 @@ func TestShouldParseTextWithDecimalAboveTwoPlaces(t *testing.T) {
 
 	parser := workingDayParser()
 	_, err := parser.ParseLine(aDate())("Category 1.753 Task-123 description")
-	assert.Equal(t, model.ErrInvalidTime, err)
+	assert.ErrorIs(t, err, model.ErrInvalidTime)
 }
 
 func TestIfTaskCouldNottBeMatchedItBecameComment(t *testing.T) {
@@ -176,3 +190,70 @@ func TestIfTaskCouldNottBeMatchedItBecameComment(t *testing.T) {
 	assert.Equal(t, "Txsk-123 description", valued.Comment)
 
 }
+
+func TestInvalidCategoryShouldPointAtCategory(t *testing.T) {
+	t.Parallel()
+	parser := workingDayParser()
+	_, err := parser.ParseLine(aDate())("  Cate 1.5 description")
+	var parseError *model.ParseError
+	assert.ErrorAs(t, err, &parseError)
+	assert.Equal(t, model.CategoryErrorKind, parseError.Kind)
+	assert.Equal(t, model.SeverityError, parseError.Severity)
+	assert.Equal(t, 2, parseError.Start)
+	assert.Equal(t, len("  Cate"), parseError.End)
+}
+
+func TestInvalidTimeShouldPointAtTime(t *testing.T) {
+	t.Parallel()
+	parser := workingDayParser()
+	_, err := parser.ParseLine(aDate())("Category 1x5 description")
+	var parseError *model.ParseError
+	assert.ErrorAs(t, err, &parseError)
+	assert.Equal(t, model.TimeErrorKind, parseError.Kind)
+	assert.Equal(t, len("Category "), parseError.Start)
+	assert.Equal(t, len("Category 1x5"), parseError.End)
+}
+
+func TestCategoryWithoutTimeShouldReportMissingTime(t *testing.T) {
+	t.Parallel()
+	parser := workingDayParser()
+	_, err := parser.ParseLine(aDate())("Category")
+	var parseError *model.ParseError
+	assert.ErrorAs(t, err, &parseError)
+	assert.ErrorIs(t, err, model.ErrInvalidTime)
+	assert.Equal(t, len("Category"), parseError.Start)
+	assert.Equal(t, len("Category"), parseError.End)
+}
+
+func TestShouldParseClockRange(t *testing.T) {
+	t.Parallel()
+	parser := workingDayParser()
+	timesheet, err := parser.ParseLine(aDate())("Category 09:15-10:45 Task-12 standup")
+	if err != nil {
+		t.Fatalf("Error parsing line: %v", err)
+	}
+	valued := timesheet.(*model.TimesheetEntry)
+	assert.Equal(t, uint8(1), valued.Hours)
+	assert.Equal(t, uint8(30), valued.Minutes)
+	assert.Equal(t, uint16(9*60+15), valued.Range.StartMinute)
+	assert.Equal(t, uint16(10*60+45), valued.Range.EndMinute)
+	assert.Equal(t, "Task-12", *valued.Task)
+	assert.Equal(t, "standup", valued.Comment)
+	assert.Equal(t, "09:15-10:45", valued.Range.String())
+}
+
+func TestShouldRejectInvalidClockRanges(t *testing.T) {
+	t.Parallel()
+	parser := workingDayParser()
+	for _, line := range []string{
+		"Category 10:45-09:15 backwards",
+		"Category 09:15-09:15 empty",
+		"Category 25:00-26:00 hours",
+		"Category 09:60-10:00 minutes",
+		"Category 09:15 missing",
+		"Category 9:5-10:00 short",
+	} {
+		_, err := parser.ParseLine(aDate())(line)
+		assert.ErrorIs(t, err, model.ErrInvalidTime, line)
+	}
+}
@@ -0,0 +1,98 @@
+package model
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// OutlineEntry is a single valid entry line of the day.
+type OutlineEntry struct {
+	Name       string
+	Time       Minutes
+	LineNumber int
+	EndColumn  int
+}
+
+// CategoryOutline groups entries of the category in order of lines.
+type CategoryOutline struct {
+	Category string
+	Time     Minutes
+	Entries  []OutlineEntry
+}
+
+func (self *CategoryOutline) FirstLine() int {
+	return self.Entries[0].LineNumber
+}
+
+func (self *CategoryOutline) LastEntry() OutlineEntry {
+	return self.Entries[len(self.Entries)-1]
+}
+
+// Fold is a block of consecutive lines with entries of the same category.
+type Fold struct {
+	Category  string
+	StartLine int
+	EndLine   int
+}
+
+func entryName(entry *TimesheetEntry) string {
+	name := strings.TrimSpace(entry.TaskName() + " " + entry.Comment)
+	if name == "" {
+		return string(entry.Category)
+	}
+	return name
+}
+
+// DayOutline lists categories in order of their first entry, invalid lines are skipped.
+func (self *Service) DayOutline(lines []string, date time.Time) []CategoryOutline {
+	var outline []CategoryOutline
+	positions := make(map[CategoryType]int)
+	for lineNumber, line := range lines {
+		entry, ok := self.ParseLine(line, date).(*TimesheetEntry)
+		if !ok {
+			continue
+		}
+		position, found := positions[entry.Category]
+		if !found {
+			position = len(outline)
+			positions[entry.Category] = position
+			outline = append(outline, CategoryOutline{Category: string(entry.Category)})
+		}
+		spent := minutesOf(entry.Hours, entry.Minutes)
+		outline[position].Time += spent
+		outline[position].Entries = append(outline[position].Entries, OutlineEntry{
+			Name:       entryName(entry),
+			Time:       spent,
+			LineNumber: lineNumber,
+			EndColumn:  utf8.RuneCountInString(line),
+		})
+	}
+	return outline
+}
+
+func closeFold(folds []Fold, current *Fold) []Fold {
+	if current == nil || current.EndLine == current.StartLine {
+		return folds
+	}
+	return append(folds, *current)
+}
+
+// CategoryFolds finds blocks of at least two consecutive entries of the same category.
+func (self *Service) CategoryFolds(lines []string, date time.Time) []Fold {
+	var folds []Fold
+	var current *Fold
+	for lineNumber, line := range lines {
+		entry, ok := self.ParseLine(line, date).(*TimesheetEntry)
+		if ok && current != nil && current.Category == string(entry.Category) {
+			current.EndLine = lineNumber
+			continue
+		}
+		folds = closeFold(folds, current)
+		current = nil
+		if ok {
+			current = &Fold{Category: string(entry.Category), StartLine: lineNumber, EndLine: lineNumber}
+		}
+	}
+	return closeFold(folds, current)
+}
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"time"
+	"unicode/utf8"
+)
+
+// LineProgress tells how much time the entry of the line took and how much the day took up to it.
+type LineProgress struct {
+	LineNumber   int
+	EndColumn    int
+	Time         Minutes
+	RunningTotal Minutes
+	Expected     Minutes
+}
+
+// DayProgress goes through valid entries of the day in order of lines, invalid lines are skipped.
+func (self *Service) DayProgress(lines []string, date time.Time) []LineProgress {
+	timesheet := TimesheetForDate(date)
+	var progress []LineProgress
+	var total Minutes
+	for lineNumber, line := range lines {
+		switch e := self.ParseLine(line, date).(type) {
+		case *Holiday, *Leave:
+			timesheet.Entries = append(timesheet.Entries, e)
+		case *TimesheetEntry:
+			timesheet.Entries = append(timesheet.Entries, e)
+			spent := minutesOf(e.Hours, e.Minutes)
+			total += spent
+			progress = append(progress, LineProgress{
+				LineNumber:   lineNumber,
+				EndColumn:    utf8.RuneCountInString(line),
+				Time:         spent,
+				RunningTotal: total,
+			})
+		}
+	}
+	expected := timesheet.PotentialWorkingTime(self.config().Week())
+	for i := range progress {
+		progress[i].Expected = expected
+	}
+	return progress
+}
@@ -0,0 +1,135 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// recurringEntry is written into days matching Weekdays or Monthdays, into every working day of working week when both are empty.
+type recurringEntry struct {
+	Category  string
+	Duration  string
+	Task      string
+	Comment   string
+	Weekdays  []string
+	Monthdays []int
+}
+
+// parseWeekday accepts full English names and their three letter abbreviations, in any case.
+func parseWeekday(text string) (time.Weekday, error) {
+	lowered := strings.ToLower(text)
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		name := strings.ToLower(day.String())
+		if lowered == name || lowered == name[:3] {
+			return day, nil
+		}
+	}
+	return time.Sunday, fmt.Errorf("unknown weekday %q", text)
+}
+
+func (entry *recurringEntry) validate(config *Config) error {
+	if !config.IsCategory(entry.Category) {
+		return fmt.Errorf("recurring entry has unknown category %q", entry.Category)
+	}
+	if entry.Duration == "" {
+		return fmt.Errorf("recurring entry of %s has no duration", entry.Category)
+	}
+	if entry.Task != "" && !config.IsTask(entry.Task) {
+		return fmt.Errorf("recurring entry of %s has task %q not starting with %s", entry.Category, entry.Task, config.Tasks.Prefix)
+	}
+	// the line must be read back as the same entry, otherwise it would be added to the day again and again
+	item, err := newParser(config).doParseLine(entry.line())
+	if err != nil {
+		return fmt.Errorf("recurring entry of %s: %w", entry.Category, err)
+	}
+	parsed, ok := item.(*TimesheetEntry)
+	if !ok || parsed.Validate() != nil || !entry.matches(parsed) {
+		return fmt.Errorf("recurring entry of %s is not a valid line: %q", entry.Category, entry.line())
+	}
+	for _, weekday := range entry.Weekdays {
+		if _, err := parseWeekday(weekday); err != nil {
+			return fmt.Errorf("recurring entry of %s: %w", entry.Category, err)
+		}
+	}
+	for _, monthday := range entry.Monthdays {
+		if monthday < 1 || monthday > 31 {
+			return fmt.Errorf("recurring entry of %s has invalid day of month %d", entry.Category, monthday)
+		}
+	}
+	return nil
+}
+
+func (entry *recurringEntry) appliesTo(date time.Time, week *WorkWeek) bool {
+	if len(entry.Weekdays) == 0 && len(entry.Monthdays) == 0 {
+		return week.IsWorkingDay(date)
+	}
+	for _, weekday := range entry.Weekdays {
+		if day, err := parseWeekday(weekday); err == nil && day == date.Weekday() {
+			return true
+		}
+	}
+	for _, monthday := range entry.Monthdays {
+		if monthday == date.Day() {
+			return true
+		}
+	}
+	return false
+}
+
+func (entry *recurringEntry) line() string {
+	fields := []string{entry.Category, entry.Duration}
+	for _, field := range []string{entry.Task, entry.Comment} {
+		if field != "" {
+			fields = append(fields, field)
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
+// matches tells whether entry of the day is the recurring one, regardless of time spent.
+func (entry *recurringEntry) matches(other *TimesheetEntry) bool {
+	return entry.Category == string(other.Category) &&
+		entry.Task == other.TaskName() &&
+		entry.Comment == other.Comment
+}
+
+func (config *Config) recurringFor(date time.Time) []recurringEntry {
+	var result []recurringEntry
+	week := config.Week()
+	for _, entry := range config.Recurring {
+		if entry.appliesTo(date, week) {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+// MissingRecurring lists lines of recurring entries of the day which are not among valid lines of the document.
+// Holidays have no recurring entries.
+func (self *Service) MissingRecurring(lines []string, date time.Time) []string {
+	dateInfo := DateInfoFrom(date)
+	if self.config().IsHoliday(&dateInfo) {
+		return nil
+	}
+	var present []*TimesheetEntry
+	for _, line := range lines {
+		if entry, ok := self.ParseLine(line, date).(*TimesheetEntry); ok {
+			present = append(present, entry)
+		}
+	}
+	var missing []string
+	for _, recurring := range self.config().recurringFor(date) {
+		found := false
+		for _, entry := range present {
+			if recurring.matches(entry) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, recurring.line())
+		}
+	}
+	return missing
+}
@@ -0,0 +1,143 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Occurrence marks columns Start to End of a word in line LineNumber.
+type Occurrence struct {
+	LineNumber int
+	Start      int
+	End        int
+}
+
+// TaskReference is occurrence of the task in file given by URI.
+type TaskReference struct {
+	URI string
+	Occurrence
+}
+
+// entryWords returns words of the line which is a valid entry, together with the entry.
+func (self *Service) entryWords(line string, date time.Time) (*TimesheetEntry, []Token, bool) {
+	entry, ok := self.ParseLine(line, date).(*TimesheetEntry)
+	if !ok {
+		return nil, nil, false
+	}
+	return entry, TokenizeFromIndex(line, 0), true
+}
+
+// taskWord returns task of the line with its position, for lines which are valid entries with task.
+func (self *Service) taskWord(line string, date time.Time) (Token, bool) {
+	entry, words, ok := self.entryWords(line, date)
+	if !ok || entry.Task == nil || len(words) < 3 || words[2].Word != *entry.Task {
+		return Token{}, false
+	}
+	return words[2], true
+}
+
+// categoryWord returns category of the line with its position, for lines which are valid entries.
+func (self *Service) categoryWord(line string, date time.Time) (Token, bool) {
+	entry, words, ok := self.entryWords(line, date)
+	if !ok || len(words) == 0 || words[0].Word != entry.Category {
+		return Token{}, false
+	}
+	return words[0], true
+}
+
+func occurrenceOf(lineNumber int, word Token) Occurrence {
+	return Occurrence{LineNumber: lineNumber, Start: word.Column, End: word.EndColumn()}
+}
+
+// TaskAt returns task under cursor placed at column of line.
+func (self *Service) TaskAt(line string, column int, date time.Time) (string, bool) {
+	task, ok := self.taskWord(line, date)
+	if !ok || column < task.Column || column > task.EndColumn() {
+		return "", false
+	}
+	return task.Word, true
+}
+
+// TaskOccurrences finds lines of the day logging time on the task.
+func (self *Service) TaskOccurrences(lines []string, task string, date time.Time) []Occurrence {
+	var occurrences []Occurrence
+	for lineNumber, line := range lines {
+		found, ok := self.taskWord(line, date)
+		if !ok || found.Word != task {
+			continue
+		}
+		occurrences = append(occurrences, occurrenceOf(lineNumber, found))
+	}
+	return occurrences
+}
+
+// FileForDate returns URI of timesheet file of the day.
+func (self *Service) FileForDate(date time.Time) string {
+	return FileURI(self.dayFilePath(date))
+}
+
+// dayFilePath follows layout read by DateFromFile, i.e. YYYY/MM/DD.tsf under project root.
+func (self *Service) dayFilePath(date time.Time) string {
+	return filepath.Join(self.projectRoot, filepath.FromSlash(date.Format(timesheetFileLayout)))
+}
+
+// FileURI names file given by path, made absolute, e.g. file:///home/me/timesheets/2025/03/06.tsf
+func FileURI(path string) string {
+	if absolute, err := filepath.Abs(path); err == nil {
+		path = absolute
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
+
+// TaskReferences finds all lines across project logging time on the task.
+// Days are taken from database, lines from opened documents (when opened returns them) or from disk.
+func (self *Service) TaskReferences(task string, opened func(uri string) []string) ([]TaskReference, error) {
+	var days []Day
+	err := self.repository.Transactional(context.TODO(), func(ctx context.Context, repository Saver, queryer Queryer) error {
+		found, err := queryer.TaskDays(ctx, task)
+		if err != nil {
+			return fmt.Errorf("failed to get days of task: %w", err)
+		}
+		days = found
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var references []TaskReference
+	for _, day := range days {
+		date := time.Time(day)
+		uri := self.FileForDate(date)
+		lines, err := linesOf(uri, opened)
+		if err != nil {
+			log.Printf("Skipping references in %s: %s", uri, err)
+			continue
+		}
+		for _, occurrence := range self.TaskOccurrences(lines, task, date) {
+			references = append(references, TaskReference{URI: uri, Occurrence: occurrence})
+		}
+	}
+	return references, nil
+}
+
+// linesOf returns lines of opened document or, when it is not opened, of the file on disk.
+func linesOf(uri string, opened func(uri string) []string) ([]string, error) {
+	if lines := opened(uri); lines != nil {
+		return lines, nil
+	}
+	path, err := uriToFilePath(uri)
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Split(string(content), "\n"), nil
+}
@@ -0,0 +1,127 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"time"
+)
+
+type timesheetFile struct {
+	path string
+	date time.Time
+}
+
+// FileError lists problems which made lines of the file unusable during reindex.
+type FileError struct {
+	Path   string
+	Errors []LineError
+}
+
+type ReindexResult struct {
+	Files  int
+	Errors []FileError
+}
+
+// timesheetFiles finds YYYY/MM/DD.tsf files under project root, ordered by date.
+func (self *Service) timesheetFiles() ([]timesheetFile, error) {
+	var files []timesheetFile
+	err := filepath.WalkDir(self.projectRoot, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || filepath.Ext(path) != TimeSheetExtension {
+			return nil
+		}
+		relativePath, err := filepath.Rel(self.projectRoot, path)
+		if err != nil {
+			return err
+		}
+		date, err := time.Parse(timesheetFileLayout, filepath.ToSlash(relativePath))
+		if err != nil {
+			log.Printf("Skipping %s: %s", path, err)
+			return nil
+		}
+		files = append(files, timesheetFile{path: path, date: date})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan project root: %w", err)
+	}
+	return files, nil
+}
+
+func onlyErrors(lineErrors []LineError) []LineError {
+	var result []LineError
+	for _, lineError := range lineErrors {
+		if lineError.Severity == SeverityError {
+			result = append(result, lineError)
+		}
+	}
+	return result
+}
+
+// ReplaceConfig switches to changed configuration and reindexes, as categories, holidays or working week
+// of stored days might be different now. Result is nil when configuration is the same as the current one,
+// e.g. when config file was saved after category was renamed.
+func (self *Service) ReplaceConfig(config *Config) (*ReindexResult, error) {
+	if reflect.DeepEqual(self.config(), config) {
+		return nil, nil
+	}
+	self.useConfig(config)
+	return self.Reindex()
+}
+
+// Reindex replaces all stored data with timesheets read from files under project root.
+// Valid lines of files with errors are still stored, the same way as when saving in editor.
+func (self *Service) Reindex() (*ReindexResult, error) {
+	files, err := self.timesheetFiles()
+	if err != nil {
+		return nil, err
+	}
+	result := &ReindexResult{}
+	var timesheets []*Timesheet
+	for _, file := range files {
+		content, err := os.ReadFile(file.path)
+		if err != nil {
+			result.Errors = append(result.Errors, FileError{Path: file.path, Errors: []LineError{timesheetError(err)}})
+			continue
+		}
+		timesheet, _, lineErrors := self.parse(string(content), file.date)
+		if problems := onlyErrors(lineErrors); len(problems) > 0 {
+			result.Errors = append(result.Errors, FileError{Path: file.path, Errors: problems})
+		}
+		if timesheet != nil {
+			timesheets = append(timesheets, timesheet)
+		}
+		result.Files++
+	}
+
+	err = self.repository.Transactional(context.TODO(), func(ctx context.Context, repository Saver, queryer Queryer) error {
+		if err := repository.Clear(ctx); err != nil {
+			return err
+		}
+		months := make(map[string]time.Time)
+		for _, timesheet := range timesheets {
+			if err := repository.Save(ctx, timesheet); err != nil {
+				return fmt.Errorf("failed to save %s: %w", timesheet.Date.String(), err)
+			}
+			months[timesheet.Date.String()[:len("2006-01")]] = time.Time(timesheet.Date)
+		}
+		for _, date := range months {
+			if err := self.updateLedger(ctx, repository, queryer, date); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to reindex: %w", err)
+	}
+	log.Printf("Reindexed %d files with %d failing", result.Files, len(result.Errors))
+	return result, nil
+}
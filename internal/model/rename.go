@@ -0,0 +1,140 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var ErrInvalidName = errors.New("invalid name")
+
+type RenameKind int
+
+const (
+	CategoryRename RenameKind = iota
+	TaskRename
+)
+
+func (k RenameKind) String() string {
+	switch k {
+	case CategoryRename:
+		return "category"
+	case TaskRename:
+		return "task"
+	default:
+		return "unknown"
+	}
+}
+
+// RenameTarget is category or task under cursor.
+type RenameTarget struct {
+	Kind RenameKind
+	Name string
+	Occurrence
+}
+
+// FileEdit lists places of the file which hold renamed name.
+type FileEdit struct {
+	URI         string
+	Occurrences []Occurrence
+}
+
+func within(word Token, column int) bool {
+	return column >= word.Column && column <= word.EndColumn()
+}
+
+// RenameTargetAt finds category or task at column of the line.
+func (self *Service) RenameTargetAt(line string, lineNumber int, column int, date time.Time) (*RenameTarget, bool) {
+	if category, ok := self.categoryWord(line, date); ok && within(category, column) {
+		return &RenameTarget{Kind: CategoryRename, Name: category.Word, Occurrence: occurrenceOf(lineNumber, category)}, true
+	}
+	if task, ok := self.taskWord(line, date); ok && within(task, column) {
+		return &RenameTarget{Kind: TaskRename, Name: task.Word, Occurrence: occurrenceOf(lineNumber, task)}, true
+	}
+	return nil, false
+}
+
+func (self *Service) validateRename(kind RenameKind, oldName string, newName string) error {
+	if newName == oldName {
+		return fmt.Errorf("%w: %s is already named so", ErrInvalidName, oldName)
+	}
+	switch kind {
+	case CategoryRename:
+		if newName == "" || strings.IndexFunc(newName, unicode.IsSpace) >= 0 {
+			return fmt.Errorf("%w: category cannot be empty or contain spaces", ErrInvalidName)
+		}
+		if self.config().IsCategory(newName) {
+			return fmt.Errorf("%w: category %s already exists", ErrInvalidName, newName)
+		}
+	case TaskRename:
+		if !self.config().IsTask(newName) {
+			return fmt.Errorf("%w: %s is not a task, tasks start with %s", ErrInvalidName, newName, self.config().Tasks.Prefix)
+		}
+	}
+	return nil
+}
+
+// Rename finds places in timesheet files which must be changed to rename category or task.
+// Nothing is changed here, ApplyRename follows once the editor applied the edit.
+// Lines are taken from opened documents (when opened returns them) or from disk.
+func (self *Service) Rename(kind RenameKind, oldName string, newName string, opened func(uri string) []string) ([]FileEdit, error) {
+	if err := self.validateRename(kind, oldName, newName); err != nil {
+		return nil, err
+	}
+	files, err := self.timesheetFiles()
+	if err != nil {
+		return nil, err
+	}
+	var edits []FileEdit
+	for _, file := range files {
+		uri := FileURI(file.path)
+		lines, err := linesOf(uri, opened)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file.path, err)
+		}
+		edit := FileEdit{URI: uri}
+		for lineNumber, line := range lines {
+			word, ok := self.categoryWord(line, file.date)
+			if kind == TaskRename {
+				word, ok = self.taskWord(line, file.date)
+			}
+			if ok && word.Word == oldName {
+				edit.Occurrences = append(edit.Occurrences, occurrenceOf(lineNumber, word))
+			}
+		}
+		if len(edit.Occurrences) > 0 {
+			edits = append(edits, edit)
+		}
+	}
+	log.Printf("Renaming %s %s to %s in %d files", kind, oldName, newName, len(edits))
+	return edits, nil
+}
+
+// ApplyRename renames category or task in stored data once the editor applied the edit of files.
+// Renamed category is switched in configuration in the same step, so lines using the new name are valid
+// before config file is saved and lines not saved yet are kept under the new name.
+func (self *Service) ApplyRename(kind RenameKind, oldName string, newName string) error {
+	if err := self.validateRename(kind, oldName, newName); err != nil {
+		return err
+	}
+	previous := self.config()
+	err := self.repository.Transactional(context.TODO(), func(ctx context.Context, repository Saver, queryer Queryer) error {
+		if err := repository.Rename(ctx, kind, oldName, newName); err != nil {
+			return err
+		}
+		if kind == CategoryRename {
+			self.useConfig(previous.withRenamedCategory(oldName, newName))
+		}
+		return nil
+	})
+	if err != nil {
+		self.useConfig(previous)
+		return err
+	}
+	log.Printf("Renamed %s %s to %s", kind, oldName, newName)
+	return nil
+}
@@ -0,0 +1,347 @@
+package model
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type ReportFormat string
+
+const (
+	TextFormat     ReportFormat = "text"
+	JSONFormat     ReportFormat = "json"
+	CSVFormat      ReportFormat = "csv"
+	MarkdownFormat ReportFormat = "markdown"
+)
+
+func ReportFormats() []ReportFormat {
+	return []ReportFormat{TextFormat, JSONFormat, CSVFormat, MarkdownFormat}
+}
+
+func ParseReportFormat(text string) (ReportFormat, error) {
+	if text == "" {
+		return TextFormat, nil
+	}
+	for _, format := range ReportFormats() {
+		if string(format) == text {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("unknown report format %q", text)
+}
+
+func (f ReportFormat) Extension() string {
+	switch f {
+	case JSONFormat:
+		return "json"
+	case CSVFormat:
+		return "csv"
+	case MarkdownFormat:
+		return "md"
+	default:
+		return "txt"
+	}
+}
+
+type Renderer interface {
+	Render(output io.Writer, report *Report) error
+}
+
+func RendererFor(format ReportFormat) (Renderer, error) {
+	switch format {
+	case TextFormat:
+		return &textRenderer{}, nil
+	case JSONFormat:
+		return &jsonRenderer{}, nil
+	case CSVFormat:
+		return &csvRenderer{}, nil
+	case MarkdownFormat:
+		return &markdownRenderer{}, nil
+	}
+	return nil, fmt.Errorf("unknown report format %q", format)
+}
+
+func clock(m Minutes) string {
+	return fmt.Sprintf("%d:%02d", m.Hours(), m.Remainder())
+}
+
+// legacyDecimal keeps the way text reports always shown hours, e.g. 1:45 as 1.75 and 1:30 as 1.5
+func legacyDecimal(m Minutes) string {
+	value := uint16(m.Remainder()) * 10 / 6
+	if value%10 == 0 {
+		return fmt.Sprintf("%d.%d", m.Hours(), value/10)
+	}
+	return fmt.Sprintf("%d.%d", m.Hours(), value)
+}
+
+// monthTotals shows worked time against required hours, e.g. 9:45/168, 32 to date
+func monthTotals(section ReportSection) string {
+	return fmt.Sprintf("%s/%d, %d to date", clock(section.Total), section.Required.Hours(), section.RequiredToDate.Hours())
+}
+
+func decimal(m Minutes) string {
+	return fmt.Sprintf("%.2f", float64(m)/60)
+}
+
+func days(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
+
+// allowanceLine tells e.g. vacation days 1.5/26, 24.5 left
+func allowanceLine(allowance *LeaveAllowance) string {
+	return fmt.Sprintf("%s days %s/%s, %s left", vacationLeave, days(allowance.Used), days(allowance.Days), days(allowance.Remaining()))
+}
+
+func signedDecimal(m SignedMinutes) string {
+	return fmt.Sprintf("%+.2f", float64(m)/60)
+}
+
+func describe(row ReportRow) string {
+	var description strings.Builder
+	if row.Task != "" {
+		description.WriteString(row.Task)
+		description.WriteString(" ")
+	}
+	description.WriteString(row.Comment)
+	return description.String()
+}
+
+func label(row ReportRow) string {
+	if row.Task == "" {
+		return row.Category
+	}
+	return row.Category + " " + row.Task
+}
+
+type textRenderer struct{}
+
+func (r *textRenderer) Render(output io.Writer, report *Report) error {
+	if report.Title != "" {
+		fmt.Fprintf(output, "%s\n", report.Title)
+	}
+	for i, section := range report.Sections {
+		if i > 0 {
+			r.separate(output, report.Sections[i-1], section)
+		}
+		r.renderSection(output, section)
+	}
+	return nil
+}
+
+func (r *textRenderer) separate(output io.Writer, previous ReportSection, current ReportSection) {
+	if current.Kind == OvertimeSection || current.Kind == LeaveSection || current.Kind == BalanceSection {
+		fmt.Fprintln(output)
+		return
+	}
+	if previous.Kind != DaySection {
+		fmt.Fprintln(output)
+	}
+	fmt.Fprintln(output, "####################")
+	fmt.Fprintln(output)
+}
+
+func (r *textRenderer) renderSection(output io.Writer, section ReportSection) {
+	switch section.Kind {
+	case DaySection:
+		fmt.Fprintf(output, "%s (%s)\n", section.Title, clock(section.Total))
+		fmt.Fprintln(output)
+		for _, row := range section.Rows {
+			fmt.Fprintf(output, "%s %s\n", row.Category, clock(row.Time))
+			for _, entry := range row.Entries {
+				fmt.Fprintf(output, "%s %s\n", legacyDecimal(entry.Time), describe(entry))
+			}
+			fmt.Fprintln(output)
+		}
+		return
+	case MonthSection:
+		fmt.Fprintf(output, "%s (%s)\n", section.Title, monthTotals(section))
+	case BalanceSection:
+		fmt.Fprintf(output, "%s (%s)\n", section.Title, section.Balance)
+		for _, row := range section.Rows {
+			fmt.Fprintf(output, "%s %s", row.Category, row.Balance)
+			if row.Comment != "" {
+				fmt.Fprintf(output, " %s", row.Comment)
+			}
+			fmt.Fprintln(output)
+		}
+		return
+	default:
+		fmt.Fprintf(output, "%s (%s)\n", section.Title, clock(section.Total))
+	}
+	for _, row := range section.Rows {
+		fmt.Fprintf(output, "%s %s\n", label(row), legacyDecimal(row.Time))
+	}
+	if section.Allowance != nil {
+		fmt.Fprintln(output, allowanceLine(section.Allowance))
+	}
+}
+
+type jsonRow struct {
+	Category       string         `json:"category"`
+	Task           string         `json:"task,omitempty"`
+	Comment        string         `json:"comment,omitempty"`
+	Minutes        Minutes        `json:"minutes"`
+	Hours          float64        `json:"hours"`
+	BalanceMinutes *SignedMinutes `json:"balanceMinutes,omitempty"`
+	Entries        []jsonRow      `json:"entries,omitempty"`
+}
+
+type jsonSection struct {
+	Kind                  string         `json:"kind"`
+	Title                 string         `json:"title"`
+	TotalMinutes          Minutes        `json:"totalMinutes"`
+	TotalHours            float64        `json:"totalHours"`
+	RequiredMinutes       *Minutes       `json:"requiredMinutes,omitempty"`
+	RequiredToDateMinutes *Minutes       `json:"requiredToDateMinutes,omitempty"`
+	BalanceMinutes        *SignedMinutes `json:"balanceMinutes,omitempty"`
+	Allowance             *jsonAllowance `json:"allowance,omitempty"`
+	Rows                  []jsonRow      `json:"rows"`
+}
+
+type jsonAllowance struct {
+	Days      float64 `json:"days"`
+	Used      float64 `json:"used"`
+	Remaining float64 `json:"remaining"`
+}
+
+type jsonReport struct {
+	Title    string        `json:"title,omitempty"`
+	Sections []jsonSection `json:"sections"`
+}
+
+type jsonRenderer struct{}
+
+func hours(m Minutes) float64 {
+	return float64(m) / 60
+}
+
+func toJSONRows(rows []ReportRow) []jsonRow {
+	result := make([]jsonRow, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, jsonRow{
+			Category: row.Category,
+			Task:     row.Task,
+			Comment:  row.Comment,
+			Minutes:  row.Time,
+			Hours:    hours(row.Time),
+			Entries:  toJSONRows(row.Entries),
+		})
+	}
+	return result
+}
+
+func (r *jsonRenderer) Render(output io.Writer, report *Report) error {
+	result := jsonReport{Title: report.Title, Sections: make([]jsonSection, 0, len(report.Sections))}
+	for _, section := range report.Sections {
+		converted := jsonSection{
+			Kind:         section.Kind.String(),
+			Title:        section.Title,
+			TotalMinutes: section.Total,
+			TotalHours:   hours(section.Total),
+			Rows:         toJSONRows(section.Rows),
+		}
+		if section.Kind == MonthSection {
+			required, requiredToDate := section.Required, section.RequiredToDate
+			converted.RequiredMinutes = &required
+			converted.RequiredToDateMinutes = &requiredToDate
+		}
+		if section.Allowance != nil {
+			converted.Allowance = &jsonAllowance{Days: section.Allowance.Days, Used: section.Allowance.Used, Remaining: section.Allowance.Remaining()}
+		}
+		if section.Kind == BalanceSection {
+			balance := section.Balance
+			converted.BalanceMinutes = &balance
+			for i := range converted.Rows {
+				rowBalance := section.Rows[i].Balance
+				converted.Rows[i].BalanceMinutes = &rowBalance
+			}
+		}
+		result.Sections = append(result.Sections, converted)
+	}
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
+
+// leaves returns single entries for rows which have them, otherwise rows themselves.
+func leaves(rows []ReportRow) []ReportRow {
+	var result []ReportRow
+	for _, row := range rows {
+		if len(row.Entries) > 0 {
+			result = append(result, row.Entries...)
+		} else {
+			result = append(result, row)
+		}
+	}
+	return result
+}
+
+type csvRenderer struct{}
+
+func (r *csvRenderer) Render(output io.Writer, report *Report) error {
+	writer := csv.NewWriter(output)
+	if err := writer.Write([]string{"section", "category", "task", "comment", "minutes", "hours"}); err != nil {
+		return err
+	}
+	for _, section := range report.Sections {
+		for _, row := range leaves(section.Rows) {
+			record := []string{section.Kind.String(), row.Category, row.Task, row.Comment, fmt.Sprintf("%d", row.Time), decimal(row.Time)}
+			if section.Kind == BalanceSection {
+				record[4], record[5] = fmt.Sprintf("%d", row.Balance), signedDecimal(row.Balance)
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+type markdownRenderer struct{}
+
+func markdownCell(text string) string {
+	return strings.ReplaceAll(text, "|", "\\|")
+}
+
+func (r *markdownRenderer) Render(output io.Writer, report *Report) error {
+	if report.Title != "" {
+		fmt.Fprintf(output, "# %s\n\n", markdownCell(report.Title))
+	}
+	for _, section := range report.Sections {
+		if section.Kind == BalanceSection {
+			r.renderBalance(output, section)
+			continue
+		}
+		if section.Kind == MonthSection {
+			fmt.Fprintf(output, "## %s (%s)\n\n", section.Title, monthTotals(section))
+		} else {
+			fmt.Fprintf(output, "## %s (%s)\n\n", section.Title, clock(section.Total))
+		}
+		fmt.Fprintln(output, "| Category | Task | Comment | Hours |")
+		fmt.Fprintln(output, "| --- | --- | --- | ---: |")
+		for _, row := range leaves(section.Rows) {
+			fmt.Fprintf(output, "| %s | %s | %s | %s |\n", markdownCell(row.Category), markdownCell(row.Task), markdownCell(row.Comment), decimal(row.Time))
+		}
+		fmt.Fprintf(output, "| **Total** | | | **%s** |\n\n", decimal(section.Total))
+		if section.Allowance != nil {
+			fmt.Fprintf(output, "%s\n\n", allowanceLine(section.Allowance))
+		}
+	}
+	return nil
+}
+
+func (r *markdownRenderer) renderBalance(output io.Writer, section ReportSection) {
+	fmt.Fprintf(output, "## %s (%s)\n\n", section.Title, section.Balance)
+	fmt.Fprintln(output, "| Month | Worked | Comment | Balance |")
+	fmt.Fprintln(output, "| --- | ---: | --- | ---: |")
+	for _, row := range section.Rows {
+		fmt.Fprintf(output, "| %s | %s | %s | %s |\n", row.Category, decimal(row.Time), markdownCell(row.Comment), signedDecimal(row.Balance))
+	}
+	fmt.Fprintf(output, "| **Total** | | | **%s** |\n\n", signedDecimal(section.Balance))
+}
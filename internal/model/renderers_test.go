@@ -0,0 +1,165 @@
+package model_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jborkows/timesheets/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func aReport() *model.Report {
+	return &model.Report{
+		Title: "For 2025-03-06",
+		Sections: []model.ReportSection{
+			{
+				Kind:  model.DaySection,
+				Title: "Daily statistics",
+				Total: 150,
+				Rows: []model.ReportRow{
+					{Category: "aaa", Time: 150, Entries: []model.ReportRow{
+						{Category: "aaa", Task: "Task-1", Comment: "first | second", Time: 90},
+						{Category: "aaa", Comment: "third", Time: 60},
+					}},
+				},
+			},
+			{
+				Kind:           model.MonthSection,
+				Title:          "Monthly statistics",
+				Total:          150,
+				Required:       168 * 60,
+				RequiredToDate: 32 * 60,
+				Rows:           []model.ReportRow{{Category: "aaa", Time: 150}},
+			},
+		},
+	}
+}
+
+func render(t *testing.T, format model.ReportFormat) string {
+	renderer, err := model.RendererFor(format)
+	assert.Nil(t, err)
+	var output bytes.Buffer
+	assert.Nil(t, renderer.Render(&output, aReport()))
+	return output.String()
+}
+
+func TestShouldRenderText(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, `For 2025-03-06
+Daily statistics (2:30)
+
+aaa 2:30
+1.5 Task-1 first | second
+1.0 third
+
+####################
+
+Monthly statistics (2:30/168, 32 to date)
+aaa 2.5
+`, render(t, model.TextFormat))
+}
+
+func TestShouldRenderCSV(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, `section,category,task,comment,minutes,hours
+daily,aaa,Task-1,first | second,90,1.50
+daily,aaa,,third,60,1.00
+monthly,aaa,,,150,2.50
+`, render(t, model.CSVFormat))
+}
+
+func TestShouldRenderMarkdown(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, `# For 2025-03-06
+
+## Daily statistics (2:30)
+
+| Category | Task | Comment | Hours |
+| --- | --- | --- | ---: |
+| aaa | Task-1 | first \| second | 1.50 |
+| aaa |  | third | 1.00 |
+| **Total** | | | **2.50** |
+
+## Monthly statistics (2:30/168, 32 to date)
+
+| Category | Task | Comment | Hours |
+| --- | --- | --- | ---: |
+| aaa |  |  | 2.50 |
+| **Total** | | | **2.50** |
+
+`, render(t, model.MarkdownFormat))
+}
+
+func TestShouldRenderJSON(t *testing.T) {
+	t.Parallel()
+	assert.JSONEq(t, `{
+  "title": "For 2025-03-06",
+  "sections": [
+    {
+      "kind": "daily",
+      "title": "Daily statistics",
+      "totalMinutes": 150,
+      "totalHours": 2.5,
+      "rows": [
+        {
+          "category": "aaa",
+          "minutes": 150,
+          "hours": 2.5,
+          "entries": [
+            {"category": "aaa", "task": "Task-1", "comment": "first | second", "minutes": 90, "hours": 1.5},
+            {"category": "aaa", "comment": "third", "minutes": 60, "hours": 1}
+          ]
+        }
+      ]
+    },
+    {
+      "kind": "monthly",
+      "title": "Monthly statistics",
+      "totalMinutes": 150,
+      "totalHours": 2.5,
+      "requiredMinutes": 10080,
+      "requiredToDateMinutes": 1920,
+      "rows": [{"category": "aaa", "minutes": 150, "hours": 2.5}]
+    }
+  ]
+}`, render(t, model.JSONFormat))
+}
+
+func TestShouldRejectUnknownFormat(t *testing.T) {
+	t.Parallel()
+	_, err := model.ParseReportFormat("xml")
+	assert.NotNil(t, err)
+	format, err := model.ParseReportFormat("")
+	assert.Nil(t, err)
+	assert.Equal(t, model.TextFormat, format)
+}
+
+func renderBalance(t *testing.T, format model.ReportFormat) string {
+	renderer, err := model.RendererFor(format)
+	assert.Nil(t, err)
+	report := &model.Report{Sections: []model.ReportSection{{
+		Kind:    model.BalanceSection,
+		Title:   "Balance",
+		Total:   300,
+		Balance: -30,
+		Rows: []model.ReportRow{
+			{Category: "2025-01", Time: 240, Balance: 60, Comment: "expired 1:00"},
+			{Category: "2025-02", Time: 60, Balance: -30},
+		},
+	}}}
+	var output bytes.Buffer
+	assert.Nil(t, renderer.Render(&output, report))
+	return output.String()
+}
+
+func TestShouldRenderBalance(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, `Balance (-0:30)
+2025-01 +1:00 expired 1:00
+2025-02 -0:30
+`, renderBalance(t, model.TextFormat))
+	assert.Equal(t, `section,category,task,comment,minutes,hours
+balance,2025-01,,expired 1:00,60,+1.00
+balance,2025-02,,,-30,-0.50
+`, renderBalance(t, model.CSVFormat))
+}
@@ -0,0 +1,299 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+type SectionKind int
+
+const (
+	DaySection SectionKind = iota
+	WeekSection
+	MonthSection
+	OvertimeSection
+	RangeSection
+	BalanceSection
+	LeaveSection
+)
+
+func (k SectionKind) String() string {
+	switch k {
+	case DaySection:
+		return "daily"
+	case WeekSection:
+		return "weekly"
+	case MonthSection:
+		return "monthly"
+	case OvertimeSection:
+		return "overtime"
+	case RangeSection:
+		return "range"
+	case BalanceSection:
+		return "balance"
+	case LeaveSection:
+		return "leave"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseSectionKind reads period of a report, only days, weeks and months have their own reports.
+func ParseSectionKind(text string) (SectionKind, error) {
+	for _, kind := range []SectionKind{DaySection, WeekSection, MonthSection} {
+		if kind.String() == text {
+			return kind, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown report period %s", text)
+}
+
+// ReportRow is time spent on category (and task). Rows of daily section list single entries in Entries.
+// Rows of balance section are months (in Category) with time worked and running balance.
+type ReportRow struct {
+	Category string
+	Task     string
+	Comment  string
+	Time     Minutes
+	Balance  SignedMinutes
+	Entries  []ReportRow
+}
+
+// ReportSection of a month tells time required in the whole month and up to the date of the report.
+// Balance is used only by balance section, Allowance by leave section when vacation allowance is configured.
+type ReportSection struct {
+	Kind           SectionKind
+	Title          string
+	Total          Minutes
+	Required       Minutes
+	RequiredToDate Minutes
+	Balance        SignedMinutes
+	Allowance      *LeaveAllowance
+	Rows           []ReportRow
+}
+
+// Report is independent of output format, see Renderer.
+type Report struct {
+	Title    string
+	Sections []ReportSection
+}
+
+func minutesOf(hours uint8, minutes uint8) Minutes {
+	return Minutes(hours)*60 + Minutes(minutes)
+}
+
+func sumRows(rows []ReportRow) Minutes {
+	var total Minutes
+	for _, row := range rows {
+		total += row.Time
+	}
+	return total
+}
+
+func sortRows(rows []ReportRow) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].Category != rows[j].Category {
+			return rows[i].Category < rows[j].Category
+		}
+		return rows[i].Task < rows[j].Task
+	})
+}
+
+func daySection(dayEntries []DayEntry) ReportSection {
+	var categoryEntries map[string][]ReportRow = make(map[string][]ReportRow)
+	for _, entry := range dayEntries {
+		if _, ok := categoryEntries[entry.Category]; !ok {
+			categoryEntries[entry.Category] = make([]ReportRow, 0)
+		}
+		if !entry.Pending {
+			continue
+		}
+		categoryEntries[entry.Category] = append(categoryEntries[entry.Category], ReportRow{
+			Category: entry.Category,
+			Task:     entry.Task,
+			Comment:  entry.Comment,
+			Time:     Minutes(entry.Hours*60 + entry.Minutes),
+		})
+	}
+	rows := make([]ReportRow, 0, len(categoryEntries))
+	for category, entries := range categoryEntries {
+		rows = append(rows, ReportRow{Category: category, Time: sumRows(entries), Entries: entries})
+	}
+	sortRows(rows)
+	return ReportSection{Kind: DaySection, Title: "Daily statistics", Total: sumRows(rows), Rows: rows}
+}
+
+func weekSection(statistics []WeeklyStatistic) ReportSection {
+	rows := make([]ReportRow, 0, len(statistics))
+	for _, entry := range statistics {
+		rows = append(rows, ReportRow{Category: entry.Category, Time: minutesOf(entry.Weekly.Hours, entry.Weekly.Minutes)})
+	}
+	sortRows(rows)
+	return ReportSection{Kind: WeekSection, Title: "Weekly statistics", Total: sumRows(rows), Rows: rows}
+}
+
+func monthlyRows(statistics []MonthlyStatistic) []ReportRow {
+	rows := make([]ReportRow, 0, len(statistics))
+	for _, entry := range statistics {
+		rows = append(rows, ReportRow{Category: entry.Category, Time: minutesOf(entry.Monthly.Hours, entry.Monthly.Minutes)})
+	}
+	sortRows(rows)
+	return rows
+}
+
+func monthSection(statistics []MonthlyStatistic, required Minutes, requiredToDate Minutes) ReportSection {
+	rows := monthlyRows(statistics)
+	return ReportSection{
+		Kind:           MonthSection,
+		Title:          "Monthly statistics",
+		Total:          sumRows(rows),
+		Required:       required,
+		RequiredToDate: requiredToDate,
+		Rows:           rows,
+	}
+}
+
+func overtimeSection(statistics []MonthlyStatistic) ReportSection {
+	rows := monthlyRows(statistics)
+	return ReportSection{Kind: OvertimeSection, Title: "Overtime", Total: sumRows(rows), Rows: rows}
+}
+
+func leaveSection(rows []ReportRow, allowance *LeaveAllowance) ReportSection {
+	return ReportSection{Kind: LeaveSection, Title: "Leave", Total: sumRows(rows), Allowance: allowance, Rows: rows}
+}
+
+func balanceSection(months []BalanceMonth) ReportSection {
+	rows := make([]ReportRow, 0, len(months))
+	for _, month := range months {
+		row := ReportRow{Category: time.Time(month.Month).Format(balanceMonthLayout), Time: month.Worked, Balance: month.Balance}
+		if month.Expired > 0 {
+			row.Comment = fmt.Sprintf("expired %s", clock(month.Expired))
+		}
+		rows = append(rows, row)
+	}
+	section := ReportSection{Kind: BalanceSection, Title: "Balance", Total: sumRows(rows), Rows: rows}
+	if len(months) > 0 {
+		section.Balance = months[len(months)-1].Balance
+	}
+	return section
+}
+
+func rangeSection(from time.Time, to time.Time, statistics []RangeStatistic) ReportSection {
+	rows := make([]ReportRow, 0, len(statistics))
+	for _, entry := range statistics {
+		if entry.Total == 0 {
+			continue
+		}
+		rows = append(rows, ReportRow{Category: entry.Category, Task: entry.Task, Time: entry.Total})
+	}
+	return ReportSection{
+		Kind:  RangeSection,
+		Title: fmt.Sprintf("Range statistics %s - %s", from.Format("2006-01-02"), to.Format("2006-01-02")),
+		Total: sumRows(rows),
+		Rows:  rows,
+	}
+}
+
+func (self *Service) daySections(date time.Time) ([]ReportSection, error) {
+	dailyStatistics, err := self.DayStatistics(date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily statistics: %w", err)
+	}
+	return []ReportSection{daySection(dailyStatistics)}, nil
+}
+
+func (self *Service) weekSections(date time.Time) ([]ReportSection, error) {
+	weeklyStatistics, err := self.WeeklyStatistics(date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get weekly statistics: %w", err)
+	}
+	return []ReportSection{weekSection(weeklyStatistics)}, nil
+}
+
+func (self *Service) monthSections(date time.Time) ([]ReportSection, error) {
+	monthlyStatistics, err := self.MonthlyStatistics(date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get monthly statistics: %w", err)
+	}
+	required, requiredToDate, err := self.MonthlyRequired(date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get required time: %w", err)
+	}
+	sections := []ReportSection{monthSection(monthlyStatistics, required, requiredToDate)}
+	overtimeStatistics, err := self.MonthlyOvertimeStatistics(date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get monthly overtime statistics: %w", err)
+	}
+	if len(overtimeStatistics) > 0 {
+		sections = append(sections, overtimeSection(overtimeStatistics))
+	}
+	leaves, err := self.LeaveStatistics(date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leave statistics: %w", err)
+	}
+	allowance, err := self.VacationAllowance(date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vacation allowance: %w", err)
+	}
+	if len(leaves) > 0 || allowance != nil {
+		sections = append(sections, leaveSection(leaves, allowance))
+	}
+	if self.config().BalanceEnabled() {
+		balance, err := self.Balance(date)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get balance: %w", err)
+		}
+		sections = append(sections, balanceSection(balance))
+	}
+	return sections, nil
+}
+
+func dayTitle(date time.Time) string {
+	return fmt.Sprintf("For %s", date.Format("2006-01-02"))
+}
+
+func (self *Service) DayReport(date time.Time) (*Report, error) {
+	sections, err := self.daySections(date)
+	if err != nil {
+		return nil, err
+	}
+	return &Report{Title: dayTitle(date), Sections: sections}, nil
+}
+
+func (self *Service) WeekReport(date time.Time) (*Report, error) {
+	sections, err := self.weekSections(date)
+	if err != nil {
+		return nil, err
+	}
+	return &Report{Sections: sections}, nil
+}
+
+func (self *Service) MonthReport(date time.Time) (*Report, error) {
+	sections, err := self.monthSections(date)
+	if err != nil {
+		return nil, err
+	}
+	return &Report{Sections: sections}, nil
+}
+
+func (self *Service) RangeReport(from time.Time, to time.Time, groupBy GroupBy) (*Report, error) {
+	statistics, err := self.RangeStatistics(from, to, groupBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get range statistics: %w", err)
+	}
+	return &Report{Sections: []ReportSection{rangeSection(from, to, statistics)}}, nil
+}
+
+// DailyReport combines statistics of the day with the ones of its week and month.
+func (self *Service) DailyReport(date time.Time) (*Report, error) {
+	report := &Report{Title: dayTitle(date)}
+	for _, sections := range []func(time.Time) ([]ReportSection, error){self.daySections, self.weekSections, self.monthSections} {
+		found, err := sections(date)
+		if err != nil {
+			return nil, err
+		}
+		report.Sections = append(report.Sections, found...)
+	}
+	return report, nil
+}
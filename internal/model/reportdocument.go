@@ -0,0 +1,109 @@
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ReportScheme names reports which are rendered on request instead of being written to disk.
+const ReportScheme = "timesheet-report"
+
+// ReportURI names report of the period containing the date, e.g. timesheet-report://weekly/2025-03-06.md
+// Extension follows configured format, so editors can pick syntax of the report.
+func (self *Service) ReportURI(kind SectionKind, date time.Time) string {
+	uri := url.URL{
+		Scheme: ReportScheme,
+		Host:   kind.String(),
+		Path:   fmt.Sprintf("/%s.%s", date.Format("2006-01-02"), self.config().ReportFormat().Extension()),
+	}
+	return uri.String()
+}
+
+func parseReportURI(uri string) (SectionKind, time.Time, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("failed to parse report URI: %w", err)
+	}
+	if parsed.Scheme != ReportScheme {
+		return 0, time.Time{}, fmt.Errorf("%s is not a report", uri)
+	}
+	kind, err := ParseSectionKind(parsed.Host)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	name := strings.TrimPrefix(parsed.Path, "/")
+	date, err := time.Parse("2006-01-02", strings.TrimSuffix(name, path.Ext(name)))
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("failed to read date of report %s: %w", uri, err)
+	}
+	return kind, date, nil
+}
+
+// ReportContent renders report named by URI from current data, so it is never stale.
+// Daily report also contains statistics of the week and month of the day.
+func (self *Service) ReportContent(uri string) (string, error) {
+	kind, date, err := parseReportURI(uri)
+	if err != nil {
+		return "", err
+	}
+	var report *Report
+	switch kind {
+	case DaySection:
+		report, err = self.DailyReport(date)
+	case WeekSection:
+		report, err = self.WeekReport(date)
+	default:
+		report, err = self.MonthReport(date)
+	}
+	if err != nil {
+		return "", err
+	}
+	return renderReport(report, self.config().ReportFormat())
+}
+
+func renderReport(report *Report, format ReportFormat) (string, error) {
+	renderer, err := RendererFor(format)
+	if err != nil {
+		return "", err
+	}
+	var output bytes.Buffer
+	if err := renderer.Render(&output, report); err != nil {
+		return "", fmt.Errorf("failed to render report: %w", err)
+	}
+	return output.String(), nil
+}
+
+// ReportFile writes report of the period containing the date to temporary directory and returns its URI,
+// for clients which cannot request content of ReportURI.
+func (self *Service) ReportFile(kind SectionKind, date time.Time) (string, error) {
+	text, err := self.ReportContent(self.ReportURI(kind, date))
+	if err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("report-timesheet-%s-%s.%s", kind, date.Format("2006-01-02"), self.config().ReportFormat().Extension())
+	file := filepath.Join(os.TempDir(), name)
+	if err := os.WriteFile(file, []byte(text), 0o644); err != nil {
+		return "", fmt.Errorf("failed to write report file: %w", err)
+	}
+	return FileURI(file), nil
+}
+
+// ExportURI names file of the month export under project root, e.g. exports/2025-03.csv
+func (self *Service) ExportURI(date time.Time, format ReportFormat) string {
+	return FileURI(filepath.Join(self.projectRoot, "exports", fmt.Sprintf("%s.%s", date.Format("2006-01"), format.Extension())))
+}
+
+// MonthExport renders report of the month containing the date, to be stored under ExportURI.
+func (self *Service) MonthExport(date time.Time, format ReportFormat) (string, error) {
+	report, err := self.MonthReport(date)
+	if err != nil {
+		return "", err
+	}
+	return renderReport(report, format)
+}
@@ -5,6 +5,14 @@ import "context"
 type Saver interface {
 	Save(ctx context.Context, timesheet *Timesheet) error
 	PendingSave(ctx context.Context, timesheet *Timesheet) error
+	// Clear removes all timesheets, both saved and pending ones.
+	Clear(ctx context.Context) error
+	// Remove drops timesheet of the day, e.g. when its file was deleted.
+	Remove(ctx context.Context, knowsAboutDate KnowsAboutDate) error
+	// Rename replaces name of category or task in all entries.
+	Rename(ctx context.Context, kind RenameKind, oldName string, newName string) error
+	// SaveBalance replaces time worked in the month, required time is not stored.
+	SaveBalance(ctx context.Context, ledger LedgerMonth) error
 }
 
 type KnowsAboutWeek interface {
@@ -28,19 +36,45 @@ type DayEntry struct {
 	Comment       string
 	Task          string
 	Category      string
+	Range         *TimeRange
 }
 
 type Queryer interface {
 	Daily(ctx context.Context, knowsAboutDate KnowsAboutDate) ([]DailyStatistic, error)
 	Weekly(ctx context.Context, knowsAboutWeek KnowsAboutWeek) ([]WeeklyStatistic, error)
 	Monthly(ctx context.Context, knowsAboutMonth KnowsAboutMonth) ([]MonthlyStatistic, error)
-	MonthlyPerCategories(ctx context.Context, categories []string, knowsAboutMonth KnowsAboutMonth) ([]MonthlyStatistic, error)
-	MonthlyOngoing(ctx context.Context, knowsAboutMonth KnowsAboutMonth) (TotalHours, error)
+	// MonthlyRegular sums time of the categories logged on working days of the month.
+	MonthlyRegular(ctx context.Context, categories []string, knowsAboutMonth KnowsAboutMonth) ([]MonthlyStatistic, error)
+	// MonthlyOvertime sums time of the overtime categories and of all work on days which are overtime as whole.
+	MonthlyOvertime(ctx context.Context, categories []string, knowsAboutMonth KnowsAboutMonth) ([]MonthlyStatistic, error)
 	DaySummary(ctx context.Context, knowsAboutDate KnowsAboutDate) ([]DayEntry, error)
+	Range(ctx context.Context, from Day, to Day, groupBy GroupBy) ([]RangeStatistic, error)
+	// RecentTasks lists saved tasks, the most recently used first.
+	RecentTasks(ctx context.Context, limit int) ([]string, error)
+	// FrequentComments lists saved comments of the task (or of the category when task is empty), the most used first.
+	FrequentComments(ctx context.Context, category string, task string, limit int) ([]string, error)
+	// TaskDays lists days with time logged on the task, in order.
+	TaskDays(ctx context.Context, task string) ([]Day, error)
+	// MatchingDays lists days with timesheet whose YYYYMMDD contains text, the latest first.
+	MatchingDays(ctx context.Context, text string, limit int) ([]Day, error)
+	// MatchingTasks lists tasks containing text, the most recently used first.
+	MatchingTasks(ctx context.Context, text string, limit int) ([]NameUse, error)
+	// MatchingCategories lists categories containing text, ordered by name.
+	MatchingCategories(ctx context.Context, text string, limit int) ([]NameUse, error)
+	// Leaves lists saved leave of days from the first to the last one, inclusive, in order of days.
+	Leaves(ctx context.Context, from Day, to Day) ([]TakenLeave, error)
+	// Ledger lists time worked in months up to the month given, in order, without required time.
+	Ledger(ctx context.Context, knowsAboutMonth KnowsAboutMonth) ([]LedgerMonth, error)
 }
 
-type TotalHours uint16
+// NameUse tells when category or task was used for the last time.
+type NameUse struct {
+	Name     string
+	LastUsed Day
+}
 
 type Repository interface {
 	Transactional(ctx context.Context, operation func(context.Context, Saver, Queryer) error) error
+	// UseConfig switches configuration used by later transactions, e.g. to tell overtime categories.
+	UseConfig(config *Config)
 }
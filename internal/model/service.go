@@ -2,45 +2,100 @@ package model
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
-	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
 type Service struct {
 	projectRoot string
-	config      *Config
-	parser      *Parser
+	configured  atomic.Pointer[configured]
 	repository  Repository
 }
 
+// configured is configuration with parser of its lines, both are replaced at once when configuration changes
+// while other goroutines read them.
+type configured struct {
+	config *Config
+	parser *Parser
+}
+
 type CleanupFunction func()
 
-func NewService(projectRoot string, config *Config, repository Repository) *Service {
-	parser := Parser{
+// newParser reads lines with categories, tasks, holidays and working week of the config.
+func newParser(config *Config) *Parser {
+	return &Parser{
 		HolidayClassifier: func(a *DateInfo) bool { return config.IsHoliday(a) },
 		IsCategory:        func(text string) bool { return config.IsCategory(text) },
 		IsTask:            func(text string) bool { return config.IsTask(text) },
+		IsLeaveKind:       func(text string) bool { return config.IsLeaveKind(text) },
+		DayNorm: func(a *DateInfo) Minutes {
+			date, err := time.Parse("2006-01-02", a.Value)
+			if err != nil {
+				return 0
+			}
+			return config.Week().Norm(date)
+		},
 	}
+}
 
-	return &Service{
+func NewService(projectRoot string, config *Config, repository Repository) *Service {
+	service := &Service{
 		projectRoot: projectRoot,
-		config:      config,
-		parser:      &parser,
 		repository:  repository,
 	}
+	service.configured.Store(&configured{config: config, parser: newParser(config)})
+	return service
+}
+
+func (self *Service) config() *Config {
+	return self.configured.Load().config
+}
+
+func (self *Service) parser() *Parser {
+	return self.configured.Load().parser
+}
+
+// useConfig switches service and stored data to the configuration.
+func (self *Service) useConfig(config *Config) {
+	self.configured.Store(&configured{config: config, parser: newParser(config)})
+	self.repository.UseConfig(config)
 }
 
 type LineError struct {
 	LineNumber int
 	LineLength int
+	Start      int
+	End        int
+	Severity   Severity
 	Err        error
 }
 
+func lineErrorFrom(lineNumber int, line string, err error) LineError {
+	var parseError *ParseError
+	if errors.As(err, &parseError) {
+		return LineError{
+			LineNumber: lineNumber,
+			LineLength: len(line),
+			Start:      parseError.Start,
+			End:        parseError.End,
+			Severity:   parseError.Severity,
+			Err:        err,
+		}
+	}
+	return LineError{LineNumber: lineNumber, LineLength: len(line), Start: 0, End: len(line), Severity: SeverityError, Err: err}
+}
+
+func timesheetError(err error) LineError {
+	return LineError{LineNumber: 0, LineLength: 0, Severity: SeverityError, Err: err}
+}
+
 type WriteMode int
 
 const (
@@ -70,7 +125,7 @@ func (self *Service) ProcessForDraft(text string, date time.Time) ([]WorkItem, [
 
 func (self *Service) ParseLine(line string, date time.Time) WorkItem {
 	dateInfo := DateInfoFrom(date)
-	parseLine := self.parser.ParseLine(dateInfo)
+	parseLine := self.parser().ParseLine(dateInfo)
 	workItem, err := parseLine(line)
 	if err != nil {
 		return nil
@@ -84,16 +139,39 @@ func (self *Service) ParseLine(line string, date time.Time) WorkItem {
 		return e
 	case *Holiday:
 		return e
+	case *Leave:
+		return e
 	}
 	return nil
 
 }
 
 func (self *Service) process(text string, date time.Time, mode WriteMode) ([]WorkItem, []LineError) {
+	timesheet, workItems, errors := self.parse(text, date)
+	if timesheet == nil {
+		if mode == SAVE {
+			// nothing valid left in the file, so stale data of the day must not be reported
+			if err := self.RemoveDay(date); err != nil {
+				errors = append(errors, timesheetError(err))
+			}
+		}
+		return workItems, errors
+	}
+	err := self.saveData(timesheet, mode)
+	if err != nil {
+		errors = append(errors, timesheetError(err))
+	}
+
+	return workItems, errors
+}
+
+// parse reads text of the day into timesheet, which is nil when text holds no valid items.
+func (self *Service) parse(text string, date time.Time) (*Timesheet, []WorkItem, []LineError) {
 	var workItems []WorkItem = nil
 	var errors []LineError = nil
+	var ranged []numberedEntry
 	dateInfo := DateInfoFrom(date)
-	parseLine := self.parser.ParseLine(dateInfo)
+	parseLine := self.parser().ParseLine(dateInfo)
 	lines := strings.Split(text, "\n")
 	for counter, line := range lines {
 		if counter == len(lines)-1 && line == "" {
@@ -101,23 +179,30 @@ func (self *Service) process(text string, date time.Time, mode WriteMode) ([]Wor
 		}
 		workItem, err := parseLine(line)
 		if err != nil {
-			errors = append(errors, LineError{LineNumber: counter, LineLength: len(line), Err: err})
+			errors = append(errors, lineErrorFrom(counter, line, err))
 			continue
 		}
 		switch e := workItem.(type) {
 		case *TimesheetEntry:
 			err := e.Validate()
 			if err != nil {
-				errors = append(errors, LineError{LineNumber: counter, LineLength: len(line), Err: err})
+				errors = append(errors, lineErrorFrom(counter, line, invalidEntry(line, err)))
 				continue
 			}
+			for _, warning := range entryWarnings(line, e) {
+				errors = append(errors, lineErrorFrom(counter, line, warning))
+			}
+			if e.Range != nil {
+				ranged = append(ranged, numberedEntry{lineNumber: counter, line: line, entry: e})
+			}
 		}
 		workItems = append(workItems, workItem)
 	}
+	errors = append(errors, validateDay(ranged)...)
 	log.Printf("Parsed %+v items", workItems)
 	log.Printf("Parsed %+v errors", errors)
 	if len(workItems) == 0 {
-		return workItems, errors
+		return nil, workItems, errors
 	}
 	timesheet := TimesheetForDate(date)
 	for _, workItem := range workItems {
@@ -125,21 +210,106 @@ func (self *Service) process(text string, date time.Time, mode WriteMode) ([]Wor
 		case *Holiday:
 			err := timesheet.AddHoliday(e)
 			if err != nil {
-				errors = append(errors, LineError{LineNumber: 0, LineLength: 0, Err: err})
+				errors = append(errors, timesheetError(err))
 			}
 		case *TimesheetEntry:
 			err := timesheet.Add(e)
 			if err != nil {
-				errors = append(errors, LineError{LineNumber: 0, LineLength: 0, Err: err})
+				errors = append(errors, timesheetError(err))
 			}
+		case *Leave:
+			timesheet.AddLeave(e)
 		}
 	}
-	err := self.saveData(timesheet, mode)
+	return timesheet, workItems, errors
+}
+
+type numberedEntry struct {
+	lineNumber int
+	line       string
+	entry      *TimesheetEntry
+}
+
+// validateDay warns when clock ranges of the day overlap or leave unaccounted time between them.
+func validateDay(ranged []numberedEntry) []LineError {
+	return append(overlappingRanges(ranged), unaccountedGaps(ranged)...)
+}
+
+func rangeWarning(current numberedEntry, err error) LineError {
+	at, _ := timeWord(current.line)
+	return lineErrorFrom(current.lineNumber, current.line, &ParseError{
+		Err:      err,
+		Kind:     TimeErrorKind,
+		Start:    at.start,
+		End:      at.end,
+		Severity: SeverityWarning,
+	})
+}
+
+// unaccountedGaps warns about entries which start later than all earlier (by clock) entries ended.
+func unaccountedGaps(ranged []numberedEntry) []LineError {
+	if len(ranged) < 2 {
+		return nil
+	}
+	byStart := slices.Clone(ranged)
+	sort.SliceStable(byStart, func(i, j int) bool {
+		return byStart[i].entry.Range.StartMinute < byStart[j].entry.Range.StartMinute
+	})
+	var errors []LineError
+	reach := byStart[0].entry.Range.EndMinute
+	for _, current := range byStart[1:] {
+		if current.entry.Range.StartMinute > reach {
+			gap := TimeRange{StartMinute: reach, EndMinute: current.entry.Range.StartMinute}
+			errors = append(errors, rangeWarning(current, fmt.Errorf("%w %s before this entry", ErrUnaccountedGap, gap.String())))
+		}
+		reach = max(reach, current.entry.Range.EndMinute)
+	}
+	return errors
+}
+
+// overlappingRanges warns about every entry whose clock range overlaps with one from an earlier line.
+func overlappingRanges(ranged []numberedEntry) []LineError {
+	var errors []LineError
+	for i, current := range ranged {
+		for _, previous := range ranged[:i] {
+			if !current.entry.Range.Overlaps(previous.entry.Range) {
+				continue
+			}
+			errors = append(errors, rangeWarning(current, fmt.Errorf("%w with line %d (%s)", ErrOverlappingRange, previous.lineNumber+1, previous.entry.Range)))
+			break
+		}
+	}
+	return errors
+}
+
+// ProcessFromDisk stores the file as saved one, used when it was changed outside of editor.
+// Returns the content read from disk.
+func (self *Service) ProcessFromDisk(uri string) (string, []LineError, error) {
+	date, err := self.ParseDateFromName(uri)
+	if err != nil {
+		return "", nil, err
+	}
+	path, err := uriToFilePath(uri)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse URI: %w", err)
+	}
+	content, err := os.ReadFile(path)
 	if err != nil {
-		errors = append(errors, LineError{LineNumber: 0, LineLength: 0, Err: err})
+		return "", nil, fmt.Errorf("failed to read %s: %w", path, err)
 	}
+	text := string(content)
+	_, errors := self.ProcessForSave(text, date)
+	return text, errors, nil
+}
 
-	return workItems, errors
+// RemoveDay forgets both saved and pending data of the day.
+func (self *Service) RemoveDay(date time.Time) error {
+	return self.repository.Transactional(context.TODO(), func(ctx context.Context, repository Saver, queryer Queryer) error {
+		if err := repository.Remove(ctx, TimesheetForDate(date)); err != nil {
+			return err
+		}
+		return self.updateLedger(ctx, repository, queryer, date)
+	})
 }
 
 func (self *Service) saveData(timesheet *Timesheet, mode WriteMode) error {
@@ -159,7 +329,7 @@ func (self *Service) saveData(timesheet *Timesheet, mode WriteMode) error {
 			if err != nil {
 				return fmt.Errorf("failed to save : %w", err)
 			}
-			return nil
+			return self.updateLedger(ctx, repository, queryer, time.Time(timesheet.Date))
 		})
 
 	}
@@ -167,7 +337,44 @@ func (self *Service) saveData(timesheet *Timesheet, mode WriteMode) error {
 }
 
 func (self *Service) PossibleCategories() []string {
-	return self.config.PossibleCategories()
+	return self.config().PossibleCategories()
+}
+
+func (self *Service) ReportFormat() ReportFormat {
+	return self.config().ReportFormat()
+}
+
+type CategoryFix struct {
+	Column      int
+	Length      int
+	Category    string
+	Suggestions []string
+}
+
+const categorySuggestionsLimit = 3
+
+// CategoryFix proposes replacements for the first word of the line when it is not a known category.
+func (self *Service) CategoryFix(line string, date time.Time) *CategoryFix {
+	dateInfo := DateInfoFrom(date)
+	_, err := self.parser().ParseLine(dateInfo)(line)
+	if !errors.Is(err, ErrInvalidCategory) {
+		return nil
+	}
+	words := TokenizeFromIndex(line, 0)
+	if len(words) == 0 {
+		return nil
+	}
+	category := words[0]
+	suggestions := self.config().ClosestCategories(category.Word, categorySuggestionsLimit)
+	if len(suggestions) == 0 {
+		return nil
+	}
+	return &CategoryFix{
+		Column:      category.Column,
+		Length:      category.EndColumn() - category.Column,
+		Category:    category.Word,
+		Suggestions: suggestions,
+	}
 }
 
 func (self *Service) ParseDateFromName(uri string) (time.Time, error) {
@@ -210,203 +417,73 @@ func (self *Service) WeeklyStatistics(date time.Time) ([]WeeklyStatistic, error)
 }
 
 func (self *Service) MonthlyStatistics(date time.Time) ([]MonthlyStatistic, error) {
-	return statistics(self, date, func(ctx context.Context, queryer Queryer, date time.Time) ([]MonthlyStatistic, error) {
-		return queryer.MonthlyPerCategories(ctx, self.config.RegularCategories(), TimesheetForDate(date))
+	result, err := statistics(self, date, func(ctx context.Context, queryer Queryer, date time.Time) ([]MonthlyStatistic, error) {
+		return queryer.MonthlyRegular(ctx, self.config().RegularCategories(), TimesheetForDate(date))
 	})
+	if err != nil {
+		return nil, err
+	}
+	required, toDate, err := self.MonthlyRequired(date)
+	if err != nil {
+		return nil, err
+	}
+	for i := range result {
+		result[i].Required = required
+		result[i].RequiredToDate = toDate
+	}
+	return result, nil
 }
 
 func (self *Service) MonthlyOvertimeStatistics(date time.Time) ([]MonthlyStatistic, error) {
 	return statistics(self, date, func(ctx context.Context, queryer Queryer, date time.Time) ([]MonthlyStatistic, error) {
-		return queryer.MonthlyPerCategories(ctx, self.config.OvertimeCategories(), TimesheetForDate(date))
+		return queryer.MonthlyOvertime(ctx, self.config().OvertimeCategories(), TimesheetForDate(date))
 	})
 }
 
-func (self *Service) MonthlyOngoingStatistics(date time.Time) (TotalHours, error) {
-	return statistics(self, date, func(ctx context.Context, queryer Queryer, date time.Time) (TotalHours, error) {
-		return queryer.MonthlyOngoing(ctx, TimesheetForDate(date))
-	})
-}
-
-type FilePath string
-
-func printDayStatistics(reportFile *os.File, dayEntries []DayEntry) {
-	var categoryFiles map[string][]DayEntry = make(map[string][]DayEntry)
-	for _, entry := range dayEntries {
-		if _, ok := categoryFiles[entry.Category]; !ok {
-			categoryFiles[entry.Category] = make([]DayEntry, 0)
-		}
-		if !entry.Pending {
-			continue
-		}
-		categoryFiles[entry.Category] = append(categoryFiles[entry.Category], entry)
-	}
-	categories := make([]string, 0, len(categoryFiles))
-	for category := range categoryFiles {
-		categories = append(categories, category)
-	}
-	sort.Strings(categories)
-
-	dailyHours := 0
-	dailyMinutes := 0
-	for _, category := range categories {
-		entries := categoryFiles[category]
-		for _, entry := range entries {
-			dailyHours += int(entry.Hours)
-			dailyMinutes += int(entry.Minutes)
-		}
-	}
-	fmt.Fprintf(reportFile, "Daily statistics (%d:%02d)\n", dailyHours+dailyMinutes/60, dailyMinutes%60)
-	fmt.Fprintln(reportFile)
-	for _, category := range categories {
-		entries := categoryFiles[category]
-		sumHours := 0
-		sumMinutes := 0
-		for _, entry := range entries {
-			sumHours += int(entry.Hours)
-			sumMinutes += int(entry.Minutes)
+// MonthlyRequired is time expected in the month of the date according to the calendar less saved leave,
+// for the whole month and up to the date inclusive.
+func (self *Service) MonthlyRequired(date time.Time) (Minutes, Minutes, error) {
+	month := TimesheetForDate(date).Month()
+	begin, end := time.Time(month.BeginDate), time.Time(month.EndDate)
+	var required, toDate Minutes
+	err := self.repository.Transactional(context.TODO(), func(ctx context.Context, repository Saver, queryer Queryer) error {
+		var err error
+		if required, err = self.requiredTime(ctx, queryer, begin, end); err != nil {
+			return fmt.Errorf("failed to get required time: %w", err)
 		}
-		fmt.Fprintf(reportFile, "%s %d:%02d\n", category, sumHours+sumMinutes/60, sumMinutes%60)
-		for _, entry := range entries {
-			var description strings.Builder
-			if entry.Task != "" {
-				description.WriteString(entry.Task)
-				description.WriteString(" ")
-			}
-			description.WriteString(entry.Comment)
-			minutes := entry.Minutes * 10 / 6
-			if minutes%10 == 0 {
-				fmt.Fprintf(reportFile, "%d.%d %s\n", entry.Hours, minutes/10, description.String())
-			} else {
-				fmt.Fprintf(reportFile, "%d.%d %s\n", entry.Hours, entry.Minutes*10/6, description.String())
-			}
+		if toDate, err = self.requiredTime(ctx, queryer, begin, date); err != nil {
+			return fmt.Errorf("failed to get required time: %w", err)
 		}
-		fmt.Fprintln(reportFile)
-	}
-}
-
-func printMinutesAsDecimal(minutes uint8) string {
-	value := uint16(minutes) * 10 / 6
-	if value%10 == 0 {
-		return fmt.Sprintf("%d", value/10)
-	} else {
-		return fmt.Sprintf("%d", value)
-	}
-}
-
-func printWeeklyStatistics(reportFile *os.File, weeklyStatistics []WeeklyStatistic) {
-
-	sumHours := 0
-	sumMinutes := 0
-	for _, entry := range weeklyStatistics {
-		sumHours += int(entry.Weekly.Hours)
-		sumMinutes += int(entry.Weekly.Minutes)
-	}
-	fmt.Fprintf(reportFile, "Weekly statistics (%d:%02d)\n", sumHours+sumMinutes/60, sumMinutes%60)
-	sort.Slice(weeklyStatistics, func(i, j int) bool {
-		return weeklyStatistics[i].Category < weeklyStatistics[j].Category
-	})
-
-	for _, entry := range weeklyStatistics {
-		fmt.Fprintf(reportFile, "%s %d.%s\n", entry.Category, int(entry.Weekly.Hours), printMinutesAsDecimal(entry.Weekly.Minutes))
-	}
-}
-
-func printMonthly(reportFile *os.File, statistics []MonthlyStatistic, hours TotalHours) {
-	sumHours := 0
-	sumMinutes := 0
-	for _, entry := range statistics {
-		sumHours += int(entry.Monthly.Hours)
-		sumMinutes += int(entry.Monthly.Minutes)
-	}
-	fmt.Fprintf(reportFile, "Monthly statistics (%d:%02d/%d)\n", sumHours+sumMinutes/60, sumMinutes%60, hours)
-	sort.Slice(statistics, func(i, j int) bool {
-		return statistics[i].Category < statistics[j].Category
+		return nil
 	})
-
-	for _, entry := range statistics {
-		fmt.Fprintf(reportFile, "%s %d.%s\n", entry.Category, int(entry.Monthly.Hours), printMinutesAsDecimal(entry.Monthly.Minutes))
-
-	}
+	return required, toDate, err
 }
 
-func printOvertimeMonthly(reportFile *os.File, statistics []MonthlyStatistic) {
-	sumHours := 0
-	sumMinutes := 0
-	for _, entry := range statistics {
-		sumHours += int(entry.Monthly.Hours)
-		sumMinutes += int(entry.Monthly.Minutes)
-	}
-	fmt.Fprintf(reportFile, "Overtime (%d:%02d)\n", sumHours+sumMinutes/60, sumMinutes%60)
-	sort.Slice(statistics, func(i, j int) bool {
-		return statistics[i].Category < statistics[j].Category
+// RequiredTime is time expected from the first to the last day, inclusive, according to the calendar less saved leave.
+func (self *Service) RequiredTime(from time.Time, to time.Time) (Minutes, error) {
+	return statistics(self, from, func(ctx context.Context, queryer Queryer, from time.Time) (Minutes, error) {
+		return self.requiredTime(ctx, queryer, from, to)
 	})
-
-	for _, entry := range statistics {
-		fmt.Fprintf(reportFile, "%s %d.%s\n", entry.Category, int(entry.Monthly.Hours), printMinutesAsDecimal(entry.Monthly.Minutes))
-
-	}
 }
 
-func (self *Service) ShowDailyStatistics(date time.Time) (FilePath, error) {
-
-	fileName := fmt.Sprintf("report-timesheet-%s.txt", date.Format("2006-01-02"))
-	fileName = filepath.Join(os.TempDir(), fileName)
-
-	if path, err := os.Stat(fileName); err == nil {
-		if err := os.Remove(path.Name()); err != nil {
-			if !os.IsNotExist(err) {
-				return "", fmt.Errorf("failed to remove report file: %w", err)
-			}
+func (self *Service) RangeStatistics(from time.Time, to time.Time, groupBy GroupBy) ([]RangeStatistic, error) {
+	var result []RangeStatistic
+	err := self.repository.Transactional(context.TODO(), func(ctx context.Context, repository Saver, queryer Queryer) error {
+		statistics, err := queryer.Range(ctx, Day(from), Day(to), groupBy)
+		if err != nil {
+			return fmt.Errorf("failed to get range statistics: %w", err)
 		}
-	}
-
-	reportFile, err := os.Create(fileName)
-	if err != nil {
-		return "", fmt.Errorf("failed to create report file: %w", err)
-	}
-	defer reportFile.Close()
-	fmt.Fprintf(reportFile, "For %s\n", date.Format("2006-01-02"))
-
-	dailyStatistics, err := self.DayStatistics(date)
-	if err != nil {
-		return "", fmt.Errorf("failed to get daily statistics: %w", err)
-	}
-	printDayStatistics(reportFile, dailyStatistics)
-	fmt.Fprintln(reportFile, "####################")
-	fmt.Fprintln(reportFile)
-	weeklyStatistics, err := self.WeeklyStatistics(date)
-	if err != nil {
-		return "", fmt.Errorf("failed to get weekly statistics: %w", err)
-	}
-	printWeeklyStatistics(reportFile, weeklyStatistics)
-	fmt.Fprintln(reportFile)
-	fmt.Fprintln(reportFile, "####################")
-	fmt.Fprintln(reportFile)
-	monthlyStatistics, err := self.MonthlyStatistics(date)
-	if err != nil {
-		return "", fmt.Errorf("failed to get monthly statistics: %w", err)
-	}
-	hours, err := self.MonthlyOngoingStatistics(date)
-	if err != nil {
-		return "", fmt.Errorf("failed to get monthly ongoing statistics: %w", err)
-	}
-
-	printMonthly(reportFile, monthlyStatistics, hours)
-	monthlyStatistics, err = self.MonthlyOvertimeStatistics(date)
+		result = statistics
+		return nil
+	})
 	if err != nil {
-		return "", fmt.Errorf("failed to get monthly overtime statistics: %w", err)
-	}
-	if len(monthlyStatistics) > 0 {
-		fmt.Fprintln(reportFile)
-		printOvertimeMonthly(reportFile, monthlyStatistics)
-
+		return nil, err
 	}
-
-	return FilePath(reportFile.Name()), nil
+	return result, nil
 }
 
 func (self *Service) ValidCategory(category string) bool {
-	return self.config.IsCategory(category)
+	return self.config().IsCategory(category)
 }
 
 func (self *Service) SemanaticTokenFrom(content []Line, date time.Time) []TokenReady {
@@ -465,6 +542,14 @@ func (self *Service) SemanaticTokenFrom(content []Line, date time.Time) []TokenR
 				})
 				log.Printf("Token %d: %s %d", i, tocken.Word, tocken.Index)
 			}
+		case *Leave:
+			words := TokenizeFromIndex(line, 0)
+			tokens = append(tokens, TokenReady{
+				Line:   i,
+				Column: words[0].Index,
+				Length: len(words[0].Word),
+				Type:   ClassType,
+			})
 
 		default:
 			continue
@@ -0,0 +1,80 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// PeriodSummary compares time worked in regular categories (including unsaved changes) with time expected.
+// ExpectedToDate is filled only for month, it is time expected up to the date of the summary.
+type PeriodSummary struct {
+	Worked         Minutes
+	Expected       Minutes
+	ExpectedToDate Minutes
+}
+
+// Summary has Balance after the month up to the date only when balance is configured.
+type Summary struct {
+	Day     PeriodSummary
+	Week    PeriodSummary
+	Month   PeriodSummary
+	Balance *SignedMinutes
+}
+
+func regularMinutes(statistic Statitic) Minutes {
+	if statistic.Overtime {
+		return 0
+	}
+	return minutesOf(statistic.Hours, statistic.Minutes)
+}
+
+// Summary shows how far along the day, its week and month are.
+func (self *Service) Summary(date time.Time) (*Summary, error) {
+	timesheet := TimesheetForDate(date)
+	summary := &Summary{}
+
+	daily, err := self.DailyStatistics(date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily statistics: %w", err)
+	}
+	for _, statistic := range daily {
+		summary.Day.Worked += regularMinutes(statistic.Dirty)
+	}
+	summary.Day.Expected, err = self.RequiredTime(date, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get required time: %w", err)
+	}
+
+	weekly, err := self.WeeklyStatistics(date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get weekly statistics: %w", err)
+	}
+	for _, statistic := range weekly {
+		summary.Week.Worked += regularMinutes(statistic.Dirty)
+	}
+	summary.Week.Expected, err = self.RequiredTime(time.Time(timesheet.Week().BeginDate), time.Time(timesheet.Week().EndDate))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get required time: %w", err)
+	}
+
+	monthly, err := self.MonthlyStatistics(date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get monthly statistics: %w", err)
+	}
+	for _, statistic := range monthly {
+		summary.Month.Worked += regularMinutes(statistic.Dirty)
+	}
+	summary.Month.Expected, summary.Month.ExpectedToDate, err = self.MonthlyRequired(date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get required time: %w", err)
+	}
+
+	balance, err := self.Balance(date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balance: %w", err)
+	}
+	if len(balance) > 0 {
+		summary.Balance = &balance[len(balance)-1].Balance
+	}
+	return summary, nil
+}
@@ -0,0 +1,88 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type SymbolKind int
+
+const (
+	DaySymbol SymbolKind = iota
+	TaskSymbol
+	CategorySymbol
+)
+
+// Symbol points to file of the day, or to the latest line using task or category.
+type Symbol struct {
+	Name   string
+	Kind   SymbolKind
+	Detail string
+	URI    string
+	Occurrence
+}
+
+const symbolsLimit = 50
+
+// Symbols finds days, tasks and categories matching query, days may be given as YYYY-MM-DD or its part.
+func (self *Service) Symbols(query string, opened func(uri string) []string) ([]Symbol, error) {
+	var days []Day
+	var tasks, categories []NameUse
+	err := self.repository.Transactional(context.TODO(), func(ctx context.Context, repository Saver, queryer Queryer) error {
+		var err error
+		if days, err = queryer.MatchingDays(ctx, strings.NewReplacer("-", "", "/", "").Replace(query), symbolsLimit); err != nil {
+			return fmt.Errorf("failed to get matching days: %w", err)
+		}
+		if tasks, err = queryer.MatchingTasks(ctx, query, symbolsLimit); err != nil {
+			return fmt.Errorf("failed to get matching tasks: %w", err)
+		}
+		if categories, err = queryer.MatchingCategories(ctx, query, symbolsLimit); err != nil {
+			return fmt.Errorf("failed to get matching categories: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	symbols := make([]Symbol, 0, len(days)+len(tasks)+len(categories))
+	for _, day := range days {
+		date := time.Time(day)
+		symbols = append(symbols, Symbol{
+			Name:   day.String(),
+			Kind:   DaySymbol,
+			Detail: date.Weekday().String(),
+			URI:    self.FileForDate(date),
+		})
+	}
+	for _, task := range tasks {
+		symbols = append(symbols, self.lastUse(task, TaskSymbol, opened))
+	}
+	for _, category := range categories {
+		symbols = append(symbols, self.lastUse(category, CategorySymbol, opened))
+	}
+	return symbols, nil
+}
+
+// lastUse points to the first line of the day when task or category was used for the last time.
+func (self *Service) lastUse(use NameUse, kind SymbolKind, opened func(uri string) []string) Symbol {
+	date := time.Time(use.LastUsed)
+	symbol := Symbol{Name: use.Name, Kind: kind, Detail: "last used " + use.LastUsed.String(), URI: self.FileForDate(date)}
+	lines, err := linesOf(symbol.URI, opened)
+	if err != nil {
+		return symbol
+	}
+	for lineNumber, line := range lines {
+		word, ok := self.categoryWord(line, date)
+		if kind == TaskSymbol {
+			word, ok = self.taskWord(line, date)
+		}
+		if ok && word.Word == use.Name {
+			symbol.Occurrence = occurrenceOf(lineNumber, word)
+			break
+		}
+	}
+	return symbol
+}
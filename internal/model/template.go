@@ -0,0 +1,65 @@
+package model
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DayTemplate is initial content of the day file: name of the holiday (or configured description) on holidays,
+// otherwise configured template lines (on working days of working week only) followed by recurring entries of the day.
+func (self *Service) DayTemplate(date time.Time) string {
+	dateInfo := DateInfoFrom(date)
+	if name, ok := self.config().HolidayName(&dateInfo); ok {
+		if name == "" {
+			name = self.config().HolidayDescription()
+		}
+		return name + "\n"
+	}
+	var lines []string
+	if self.config().Week().IsWorkingDay(date) {
+		lines = append(lines, self.config().Template.Lines...)
+	}
+	lines = append(lines, self.MissingRecurring(lines, date)...)
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func (self *Service) DayExists(date time.Time) bool {
+	return Exists(self.dayFilePath(date))
+}
+
+// CreatedDay is file of the day, Errors are problems of template lines which could not be stored.
+type CreatedDay struct {
+	Path    string
+	Created bool
+	Errors  []LineError
+}
+
+// CreateDay writes file of the day from template and stores its entries, existing file is left untouched.
+func (self *Service) CreateDay(date time.Time) (*CreatedDay, error) {
+	path := self.dayFilePath(date)
+	if Exists(path) {
+		return &CreatedDay{Path: path}, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create directory of %s: %w", path, err)
+	}
+	text := self.DayTemplate(date)
+	if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	timesheet, _, lineErrors := self.parse(text, date)
+	day := &CreatedDay{Path: path, Created: true, Errors: onlyErrors(lineErrors)}
+	if timesheet == nil {
+		return day, nil
+	}
+	if err := self.saveData(timesheet, SAVE); err != nil {
+		return day, fmt.Errorf("failed to store %s: %w", path, err)
+	}
+	return day, nil
+}
@@ -16,12 +16,31 @@ type Holiday struct {
 	Description string
 }
 
+// TimeRange holds clock times of an entry as minutes since midnight.
+type TimeRange struct {
+	StartMinute uint16
+	EndMinute   uint16
+}
+
+func (r *TimeRange) Duration() uint16 {
+	return r.EndMinute - r.StartMinute
+}
+
+func (r *TimeRange) Overlaps(other *TimeRange) bool {
+	return r.StartMinute < other.EndMinute && other.StartMinute < r.EndMinute
+}
+
+func (r *TimeRange) String() string {
+	return fmt.Sprintf("%02d:%02d-%02d:%02d", r.StartMinute/60, r.StartMinute%60, r.EndMinute/60, r.EndMinute%60)
+}
+
 type TimesheetEntry struct {
 	Hours    uint8
 	Minutes  uint8
 	Comment  string
 	Task     *string
 	Category CategoryType
+	Range    *TimeRange
 }
 
 func (self *TimesheetEntry) TaskName() string {
@@ -137,13 +156,15 @@ func (t *Timesheet) PotentialTotalTime() uint8 {
 	return 8
 }
 
-func (t *Timesheet) PotentialWorkingTime() uint8 {
+// PotentialWorkingTime is norm of the day in the working week less leave, none on holidays.
+func (t *Timesheet) PotentialWorkingTime(week *WorkWeek) Minutes {
 	for _, entry := range t.Entries {
 		if entry.IsHoliday() {
 			return 0
 		}
 	}
-	return 8
+	norm := week.Norm(time.Time(t.Date))
+	return norm - min(norm, t.leaveTime())
 }
 
 func (t *Timesheet) WorkingTime() float32 {
@@ -153,6 +174,9 @@ func (t *Timesheet) WorkingTime() float32 {
 		if entry.IsHoliday() {
 			continue
 		}
+		if _, ok := entry.(*Leave); ok {
+			continue
+		}
 		entry, ok := entry.(*TimesheetEntry)
 		if !ok {
 			panic("invalid entry type")
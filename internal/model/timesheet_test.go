@@ -74,7 +74,7 @@ func TestIfOneHolidayThenTimesheetShouldBeSpecific(t *testing.T) {
 	if error != nil {
 		t.Errorf("Error adding holiday: %v", error)
 	}
-	assert.Equal(t, uint8(0), timesheet.PotentialWorkingTime())
+	assert.Equal(t, model.Minutes(0), timesheet.PotentialWorkingTime(model.DefaultWorkWeek()))
 	assert.Equal(t, uint8(8), timesheet.PotentialTotalTime())
 	assert.Equal(t, float32(0), timesheet.WorkingTime())
 }
@@ -155,7 +155,7 @@ func TestShouldSumUpAllEntries(t *testing.T) {
 	err = timesheet.AddEntry(entry)
 	assert.Nil(t, err)
 
-	assert.Equal(t, uint8(8), timesheet.PotentialWorkingTime())
+	assert.Equal(t, model.Minutes(8*60), timesheet.PotentialWorkingTime(model.DefaultWorkWeek()))
 	assert.Equal(t, uint8(8), timesheet.PotentialTotalTime())
 	assert.Equal(t, float32(6.5), timesheet.WorkingTime())
 
@@ -176,7 +176,7 @@ func TestShouldAllEntriesCouldSumUpAbove8hours(t *testing.T) {
 	err = timesheet.AddEntry(entry)
 	assert.Nil(t, err)
 
-	assert.Equal(t, uint8(8), timesheet.PotentialWorkingTime())
+	assert.Equal(t, model.Minutes(8*60), timesheet.PotentialWorkingTime(model.DefaultWorkWeek()))
 	assert.Equal(t, uint8(8), timesheet.PotentialTotalTime())
 	assert.Equal(t, float32(8.5), timesheet.WorkingTime())
 
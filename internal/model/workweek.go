@@ -0,0 +1,83 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// workWeekDefinition gives hours expected on weekdays, e.g. {mon = 8, fri = 6}, days not listed are not working days.
+// Monday to Friday by 8 hours when empty. Work on days which are not working days is overtime unless WeekendOvertime is false.
+type workWeekDefinition struct {
+	Hours           map[string]float64
+	WeekendOvertime *bool
+}
+
+// WorkWeek tells how long each day of the week is expected to be worked.
+type WorkWeek struct {
+	norms           [7]Minutes
+	weekendOvertime bool
+}
+
+func DefaultWorkWeek() *WorkWeek {
+	week := &WorkWeek{weekendOvertime: true}
+	for day := time.Monday; day <= time.Friday; day++ {
+		week.norms[day] = 8 * 60
+	}
+	return week
+}
+
+func (definition *workWeekDefinition) validate() error {
+	for day, hours := range definition.Hours {
+		if _, err := parseWeekday(day); err != nil {
+			return fmt.Errorf("working week: %w", err)
+		}
+		if hours < 0 || hours > 24 {
+			return fmt.Errorf("working week: invalid hours %v on %s", hours, day)
+		}
+	}
+	return nil
+}
+
+func (definition *workWeekDefinition) workWeek() *WorkWeek {
+	week := DefaultWorkWeek()
+	if len(definition.Hours) > 0 {
+		week.norms = [7]Minutes{}
+		for day, hours := range definition.Hours {
+			if weekday, err := parseWeekday(day); err == nil {
+				week.norms[weekday] = Minutes(hours * 60)
+			}
+		}
+	}
+	if definition.WeekendOvertime != nil {
+		week.weekendOvertime = *definition.WeekendOvertime
+	}
+	return week
+}
+
+// Norm is time expected to be worked on the date, holidays are not taken into account.
+func (w *WorkWeek) Norm(date time.Time) Minutes {
+	return w.norms[date.Weekday()]
+}
+
+func (w *WorkWeek) IsWorkingDay(date time.Time) bool {
+	return w.Norm(date) > 0
+}
+
+// IsOvertimeDay tells whether all work of the date is overtime.
+func (w *WorkWeek) IsOvertimeDay(date time.Time) bool {
+	return w.weekendOvertime && !w.IsWorkingDay(date)
+}
+
+// RequiredTime sums norms of days from the first to the last one, inclusive, skipping holidays.
+func (config *Config) RequiredTime(from time.Time, to time.Time) Minutes {
+	week := config.Week()
+	var total Minutes
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		info := DateInfoFrom(day)
+		if config.IsHoliday(&info) {
+			continue
+		}
+		total += week.Norm(day)
+	}
+	return total
+}